@@ -0,0 +1,273 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DropPolicy defines what AsyncWriter does with a log entry when its queue is full.
+type DropPolicy int
+
+const (
+	// DropNewest drops the entry being written.
+	DropNewest DropPolicy = iota
+	// DropOldest drops the oldest queued entry to make room for the entry being written.
+	DropOldest
+	// Block blocks the write until there is room in the queue.
+	Block
+)
+
+const (
+	defaultAsyncQueueSize      = 1024
+	defaultAsyncReportInterval = time.Minute
+	asyncFlushPollInterval     = time.Millisecond
+
+	droppedEntriesKey = "dropped_entries"
+)
+
+// ErrWriterClosed is returned when writing to a closed AsyncWriter.
+var ErrWriterClosed = errors.New("async log writer is closed")
+
+// AsyncWriterConfig configures AsyncWriter.
+type AsyncWriterConfig struct {
+	// QueueSize is the maximum number of queued entries. Defaults to 1024.
+	QueueSize int
+	// DropPolicy defines what happens when the queue is full. Defaults to DropNewest.
+	DropPolicy DropPolicy
+	// ReportInterval is how often OnDropped is called when entries were dropped. Defaults to 1 minute.
+	ReportInterval time.Duration
+	// OnDropped is called with the number of entries dropped since the previous call,
+	// every ReportInterval and once more on Close.
+	OnDropped func(dropped uint64)
+}
+
+// AsyncWriter is an io.Writer which queues the log entries and writes them to the underlying writer
+// in a separate goroutine, so that logging does not block on slow outputs.
+// Use Flush or Close on shutdown to make sure that the queued entries are written.
+type AsyncWriter struct {
+	out            io.Writer
+	outMu          sync.Mutex
+	queue          chan []byte
+	dropPolicy     DropPolicy
+	onDropped      func(dropped uint64)
+	reportInterval time.Duration
+
+	enqueued  uint64
+	processed uint64
+	dropped   uint64
+	// reported is the number of dropped entries already passed to onDropped
+	reported uint64
+
+	mu     sync.RWMutex
+	closed bool
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewAsyncWriter returns AsyncWriter writing to out and starts its background goroutines.
+func NewAsyncWriter(out io.Writer, conf AsyncWriterConfig) *AsyncWriter {
+	w := newAsyncWriter(out, conf)
+	w.start()
+	return w
+}
+
+func newAsyncWriter(out io.Writer, conf AsyncWriterConfig) *AsyncWriter {
+	if conf.QueueSize <= 0 {
+		conf.QueueSize = defaultAsyncQueueSize
+	}
+	if conf.ReportInterval <= 0 {
+		conf.ReportInterval = defaultAsyncReportInterval
+	}
+
+	return &AsyncWriter{
+		out:            out,
+		queue:          make(chan []byte, conf.QueueSize),
+		dropPolicy:     conf.DropPolicy,
+		onDropped:      conf.OnDropped,
+		reportInterval: conf.ReportInterval,
+		stop:           make(chan struct{}),
+	}
+}
+
+func (w *AsyncWriter) start() {
+	w.wg.Add(2)
+	go w.run()
+	go w.report()
+}
+
+// Write queues a copy of p. When the queue is full, p or the oldest queued entry is dropped
+// or the write blocks, depending on the drop policy.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	entry := make([]byte, len(p))
+	copy(entry, p)
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+
+	switch w.dropPolicy {
+	case Block:
+		w.queue <- entry
+		atomic.AddUint64(&w.enqueued, 1)
+	case DropOldest:
+		for !w.tryEnqueue(entry) {
+			select {
+			case <-w.queue:
+				atomic.AddUint64(&w.dropped, 1)
+				atomic.AddUint64(&w.processed, 1)
+			default:
+			}
+		}
+	default:
+		if !w.tryEnqueue(entry) {
+			atomic.AddUint64(&w.dropped, 1)
+		}
+	}
+	return len(p), nil
+}
+
+func (w *AsyncWriter) tryEnqueue(entry []byte) bool {
+	select {
+	case w.queue <- entry:
+		atomic.AddUint64(&w.enqueued, 1)
+		return true
+	default:
+		return false
+	}
+}
+
+// Dropped returns the total number of dropped entries.
+func (w *AsyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Flush waits until all the entries queued before the call are written or the context is done.
+func (w *AsyncWriter) Flush(ctx context.Context) error {
+	target := atomic.LoadUint64(&w.enqueued)
+	ticker := time.NewTicker(asyncFlushPollInterval)
+	defer ticker.Stop()
+	for atomic.LoadUint64(&w.processed) < target {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// Close writes all the queued entries, reports the entries dropped since the last report and stops the background goroutines.
+// Writing to a closed AsyncWriter returns ErrWriterClosed.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	w.mu.Unlock()
+
+	close(w.stop)
+	w.wg.Wait()
+	w.reportDropped()
+	return nil
+}
+
+func (w *AsyncWriter) run() {
+	defer w.wg.Done()
+	for {
+		select {
+		case entry := <-w.queue:
+			w.write(entry)
+		case <-w.stop:
+			for {
+				select {
+				case entry := <-w.queue:
+					w.write(entry)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (w *AsyncWriter) write(entry []byte) {
+	w.writeUnqueued(entry)
+	atomic.AddUint64(&w.processed, 1)
+}
+
+// writeUnqueued writes p to the underlying writer, bypassing the queue.
+// The writes are serialised, so that the entries written from the report goroutine are not interleaved with the queued ones.
+func (w *AsyncWriter) writeUnqueued(p []byte) {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+	// The errors can't be returned to the caller, as with logrus the output errors are just ignored.
+	_, _ = w.out.Write(p)
+}
+
+func (w *AsyncWriter) report() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.reportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.reportDropped()
+		}
+	}
+}
+
+// reportDropped calls onDropped with the number of entries dropped since the previous call.
+// It is called from the report goroutine and from Close, after the goroutine has stopped.
+func (w *AsyncWriter) reportDropped() {
+	dropped := atomic.LoadUint64(&w.dropped)
+	if dropped > w.reported && w.onDropped != nil {
+		w.onDropped(dropped - w.reported)
+	}
+	w.reported = dropped
+}
+
+// SetAsyncOutput makes the logger write asynchronously to its current output through AsyncWriter.
+// Unless OnDropped is configured, the number of dropped entries is periodically logged as a warning.
+// The warning is written directly to the current output, as it would be dropped from the full queue as well.
+// The returned AsyncWriter should be flushed or closed on shutdown.
+func (ulog *UPPLogger) SetAsyncOutput(conf AsyncWriterConfig) *AsyncWriter {
+	w := newAsyncWriter(ulog.Out, conf)
+	if w.onDropped == nil {
+		w.onDropped = func(dropped uint64) {
+			ulog.logDropped(w, dropped)
+		}
+	}
+	w.start()
+	ulog.Out = w
+	return w
+}
+
+// logDropped logs the number of the entries dropped by w as a warning, writing it to the output of w without queueing it.
+// The logger hooks are not fired for the warning.
+func (ulog *UPPLogger) logDropped(w *AsyncWriter, dropped uint64) {
+	if ulog.level() < logrus.WarnLevel {
+		return
+	}
+	entry := logrus.NewEntry(ulog.Logger).WithField(droppedEntriesKey, dropped)
+	entry.Time = time.Now()
+	entry.Level = logrus.WarnLevel
+	entry.Message = "Log entries were dropped as the log output is too slow"
+	serialized, err := ulog.Formatter.Format(entry)
+	if err != nil || len(serialized) == 0 {
+		return
+	}
+	w.writeUnqueued(serialized)
+}
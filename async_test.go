@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingWriter blocks every write until it is released.
+type blockingWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{started: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.started <- struct{}{}
+	<-w.release
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *blockingWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncWriterDropNewest(t *testing.T) {
+	out := newBlockingWriter()
+	w := NewAsyncWriter(out, AsyncWriterConfig{QueueSize: 2, DropPolicy: DropNewest})
+
+	w.Write([]byte("1\n"))
+	<-out.started
+	for _, e := range []string{"2\n", "3\n", "4\n", "5\n"} {
+		n, err := w.Write([]byte(e))
+		assert.NoError(t, err)
+		assert.Equal(t, len(e), n)
+	}
+	assert.Equal(t, uint64(2), w.Dropped())
+
+	close(out.release)
+	require.NoError(t, w.Close())
+	assert.Equal(t, "1\n2\n3\n", out.String())
+}
+
+func TestAsyncWriterDropOldest(t *testing.T) {
+	out := newBlockingWriter()
+	w := NewAsyncWriter(out, AsyncWriterConfig{QueueSize: 2, DropPolicy: DropOldest})
+
+	w.Write([]byte("1\n"))
+	<-out.started
+	for _, e := range []string{"2\n", "3\n", "4\n", "5\n"} {
+		w.Write([]byte(e))
+	}
+	assert.Equal(t, uint64(2), w.Dropped())
+
+	close(out.release)
+	require.NoError(t, w.Close())
+	assert.Equal(t, "1\n4\n5\n", out.String())
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+	out := newBlockingWriter()
+	w := NewAsyncWriter(out, AsyncWriterConfig{QueueSize: 1, DropPolicy: Block})
+
+	w.Write([]byte("1\n"))
+	<-out.started
+	w.Write([]byte("2\n"))
+
+	written := make(chan struct{})
+	go func() {
+		w.Write([]byte("3\n"))
+		close(written)
+	}()
+	select {
+	case <-written:
+		t.Fatal("write should block when the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(out.release)
+	<-written
+	require.NoError(t, w.Close())
+	assert.Equal(t, "1\n2\n3\n", out.String())
+	assert.Equal(t, uint64(0), w.Dropped())
+}
+
+func TestAsyncWriterFlush(t *testing.T) {
+	out := newBlockingWriter()
+	w := NewAsyncWriter(out, AsyncWriterConfig{})
+	defer w.Close()
+
+	w.Write([]byte("1\n"))
+	w.Write([]byte("2\n"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, w.Flush(ctx))
+
+	close(out.release)
+	require.NoError(t, w.Flush(context.Background()))
+	assert.Equal(t, "1\n2\n", out.String())
+}
+
+func TestAsyncWriterClosed(t *testing.T) {
+	w := NewAsyncWriter(new(bytes.Buffer), AsyncWriterConfig{})
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	_, err := w.Write([]byte("1\n"))
+	assert.Equal(t, ErrWriterClosed, err)
+}
+
+func TestUPPLoggerSetAsyncOutputReportsDropped(t *testing.T) {
+	for _, policy := range []DropPolicy{DropNewest, DropOldest} {
+		out := newBlockingWriter()
+		ulog := NewUPPInfoLogger(testServiceName)
+		ulog.Out = out
+		// The dropped entries are reported on Close, long before the first report interval
+		w := ulog.SetAsyncOutput(AsyncWriterConfig{QueueSize: 1, DropPolicy: policy, ReportInterval: time.Hour})
+
+		ulog.Info(testMsg)
+		<-out.started
+		for i := 0; i < 4; i++ {
+			ulog.Info(testMsg)
+		}
+		require.Equal(t, uint64(3), w.Dropped())
+		close(out.release)
+		require.NoError(t, w.Close())
+
+		var warnings []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var logged map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &logged))
+			if logged[DefaultKeyLogLevel] == "warning" {
+				warnings = append(warnings, logged)
+			}
+		}
+		require.Len(t, warnings, 1, "The dropped entries should be reported with policy %v", policy)
+		assert.Equal(t, float64(3), warnings[0][droppedEntriesKey])
+	}
+}
+
+func TestUPPLoggerSetAsyncOutputReportsDroppedPeriodically(t *testing.T) {
+	out := newBlockingWriter()
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = out
+	w := ulog.SetAsyncOutput(AsyncWriterConfig{QueueSize: 1, ReportInterval: time.Millisecond})
+	defer w.Close()
+
+	ulog.Info(testMsg)
+	<-out.started
+	ulog.Info(testMsg)
+	ulog.Info(testMsg)
+
+	close(out.release)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), `"dropped_entries":1`) {
+		require.True(t, time.Now().Before(deadline), "The dropped entries should be reported before Close")
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const maxCallerDepth = 32
+
+var (
+	// loggerPackage is the import path of this package, e.g. github.com/Financial-Times/go-logger/v2
+	loggerPackage = reflect.TypeOf(ftJSONFormatter{}).PkgPath()
+	logrusPackage = reflect.TypeOf(logrus.Entry{}).PkgPath()
+	slogPackage   = "log/slog"
+)
+
+// findCaller returns the first frame of the call stack which is not part of the logging libraries,
+// i.e. the frame of the code that actually called the logger.
+func findCaller() (runtime.Frame, bool) {
+	pcs := make([]uintptr, maxCallerDepth)
+	// skip runtime.Callers and findCaller
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isLoggingFrame(frame) {
+			return frame, true
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+func isLoggingFrame(frame runtime.Frame) bool {
+	if frame.Function == "" || strings.HasPrefix(frame.File, "<autogenerated>") {
+		return true
+	}
+	pkg := functionPackage(frame.Function)
+	switch pkg {
+	case logrusPackage, slogPackage:
+		return true
+	case loggerPackage:
+		// the tests of this package are callers as any other code
+		return !strings.HasSuffix(frame.File, "_test.go")
+	}
+	return false
+}
+
+// functionPackage returns the import path of the package from the fully qualified function name,
+// e.g. github.com/sirupsen/logrus from github.com/sirupsen/logrus.(*Entry).Info.
+func functionPackage(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	if lastSlash < 0 {
+		lastSlash = 0
+	}
+	if dot := strings.Index(function[lastSlash:], "."); dot >= 0 {
+		return function[:lastSlash+dot]
+	}
+	return function
+}
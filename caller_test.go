@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportCaller(t *testing.T) {
+	conf := KeyNamesConfig{KeyCaller: "test-caller-key"}
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName, conf)
+	ulog.Out = out
+	ulog.SetReportCaller(true)
+
+	_, file, line, _ := runtime.Caller(0)
+	ulog.WithTransactionID(testTID).WithUUID("test-uuid").Info(testMsg)
+
+	var logLine map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logLine))
+	assert.Equal(t, file+":"+strconv.Itoa(line+1), logLine[conf.KeyCaller])
+	assert.Equal(t, loggerPackage+".TestReportCaller", logLine[DefaultKeyFunction])
+}
+
+func TestReportCallerFromLoggerMethods(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = out
+	ulog.SetReportCaller(true)
+
+	logServiceStarted := func() { ulog.LogServiceStartedEvent(8080) }
+	logServiceStarted()
+
+	var logLine map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logLine))
+	assert.Equal(t, loggerPackage+".TestReportCallerFromLoggerMethods.func1", logLine[DefaultKeyFunction])
+}
+
+func TestReportCallerOption(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithOutput(out), WithReportCaller())
+	require.NoError(t, err)
+
+	_, file, line, _ := runtime.Caller(0)
+	ulog.Error(testMsg)
+
+	var logLine map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logLine))
+	assert.Equal(t, file+":"+strconv.Itoa(line+1), logLine[DefaultKeyCaller])
+	assert.Equal(t, loggerPackage+".TestReportCallerOption", logLine[DefaultKeyFunction])
+}
+
+func TestReportCallerFormattedByHook(t *testing.T) {
+	ulog, err := New(testServiceName, WithOutput(ioutil.Discard), WithReportCaller())
+	require.NoError(t, err)
+	hook := &formattingHook{}
+	ulog.AddHook(hook)
+
+	_, file, line, _ := runtime.Caller(0)
+	ulog.WithTransactionID(testTID).Warn(testMsg)
+
+	var logLine map[string]string
+	require.NoError(t, json.Unmarshal([]byte(hook.formatted), &logLine))
+	assert.Equal(t, file+":"+strconv.Itoa(line+1), logLine[DefaultKeyCaller], "The caller should be the same for the hooks")
+	assert.Equal(t, loggerPackage+".TestReportCallerFormattedByHook", logLine[DefaultKeyFunction])
+}
+
+func TestReportCallerWithCustomFormatter(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithOutput(out), WithReportCaller(), WithFormatter(&logrus.JSONFormatter{}))
+	require.NoError(t, err)
+
+	ulog.Info(testMsg)
+
+	var logLine map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logLine))
+	assert.Equal(t, loggerPackage+".TestReportCallerWithCustomFormatter", logLine[DefaultKeyFunction])
+}
+
+func TestReportCallerDisabledByDefault(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = out
+
+	ulog.Info(testMsg)
+
+	var logLine map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logLine))
+	assert.NotContains(t, logLine, DefaultKeyCaller)
+	assert.NotContains(t, logLine, DefaultKeyFunction)
+}
+
+func TestFunctionPackage(t *testing.T) {
+	assert.Equal(t, "github.com/sirupsen/logrus", functionPackage("github.com/sirupsen/logrus.(*Entry).Info"))
+	assert.Equal(t, "github.com/Financial-Times/go-logger/v2", functionPackage("github.com/Financial-Times/go-logger/v2.(*LogEntry).WithField"))
+	assert.Equal(t, "github.com/Financial-Times/go-logger/v2/test", functionPackage("github.com/Financial-Times/go-logger/v2/test.TestAssertHasField"))
+	assert.Equal(t, "main", functionPackage("main.main.func1"))
+	assert.Equal(t, "log/slog", functionPackage("log/slog.(*Logger).log"))
+}
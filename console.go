@@ -0,0 +1,146 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	consoleTimestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
+	colourReset  = "\x1b[0m"
+	colourBold   = "\x1b[1m"
+	colourRed    = "\x1b[31m"
+	colourYellow = "\x1b[33m"
+	colourBlue   = "\x1b[34m"
+	colourCyan   = "\x1b[36m"
+	colourGrey   = "\x1b[90m"
+)
+
+// consoleFormatter formats the logs in a human-readable format for local development, e.g.
+//
+//	2020-03-04T05:06:07.000Z INFO  Content published transaction_id=tid_test uuid=... event=Publish duration=42
+//
+// It logs the same fields as ftJSONFormatter, sharing its options, except for the service name which is the same on every line.
+// The time and the message are followed by the transaction ID, the UUID and the event name, if present,
+// and then by the rest of the fields as key=value pairs sorted by key.
+// The error stack traces are logged on separate indented lines after the entry.
+// If colours is set, the level and the UPP fields are colourised with ANSI escape codes.
+type consoleFormatter struct {
+	*ftJSONFormatter
+	colours bool
+}
+
+func newConsoleFormatter(serviceName string, keyConf *KeyNamesConfig, colours bool) *consoleFormatter {
+	return &consoleFormatter{ftJSONFormatter: newFTJSONFormatter(serviceName, keyConf), colours: colours}
+}
+
+func (f *consoleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	state := getFormatState()
+	defer putFormatState(state)
+	if err := f.fields(entry, state.data); err != nil {
+		return []byte{}, err
+	}
+	data := state.data
+
+	b := new(bytes.Buffer)
+	b.WriteString(consoleTime(data[f.keyConf.KeyTime]))
+	b.WriteByte(' ')
+	f.writeColoured(b, levelColour(entry.Level), fmt.Sprintf("%-5s", consoleLevel(entry.Level)))
+	if msg, found := data[f.keyConf.KeyMsg]; found {
+		b.WriteByte(' ')
+		fmt.Fprint(b, msg)
+	}
+
+	uppKeys := []string{f.keyConf.KeyTransactionID, f.keyConf.KeyUUID, f.keyConf.KeyEventName}
+	for _, k := range uppKeys {
+		if v, found := data[k]; found {
+			b.WriteByte(' ')
+			f.writeColoured(b, colourCyan, k+"="+f.textValue(v, false))
+		}
+	}
+
+	skip := map[string]bool{
+		f.keyConf.KeyTime:        true,
+		f.keyConf.KeyLogLevel:    true,
+		f.keyConf.KeyMsg:         true,
+		f.keyConf.KeyServiceName: true,
+	}
+	for _, k := range uppKeys {
+		skip[k] = true
+	}
+	var keys, stackKeys []string
+	for k := range data {
+		switch {
+		case skip[k]:
+		case strings.HasSuffix(k, errorStackKeySuffix):
+			stackKeys = append(stackKeys, k)
+		default:
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteByte(' ')
+		f.writeColoured(b, colourBold, k)
+		b.WriteString("=" + f.textValue(data[k], false))
+	}
+	b.WriteByte('\n')
+
+	sort.Strings(stackKeys)
+	for _, k := range stackKeys {
+		f.writeColoured(b, colourGrey, k+":")
+		b.WriteByte('\n')
+		frames, ok := data[k].([]string)
+		if !ok {
+			frames = []string{f.textValue(data[k], false)}
+		}
+		for _, frame := range frames {
+			b.WriteString("    " + frame + "\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+func (f *consoleFormatter) writeColoured(b *bytes.Buffer, colour string, s string) {
+	if !f.colours {
+		b.WriteString(s)
+		return
+	}
+	b.WriteString(colour + s + colourReset)
+}
+
+// consoleTime returns the time field in a fixed width format, so that the entries are aligned.
+// The time fields which are not in the UPP time format are returned as they are.
+func consoleTime(value interface{}) string {
+	s := fmt.Sprint(value)
+	t, err := time.Parse(timestampFormat, s)
+	if err != nil {
+		return s
+	}
+	return t.Format(consoleTimestampFormat)
+}
+
+func consoleLevel(level logrus.Level) string {
+	if level == logrus.WarnLevel {
+		return "WARN"
+	}
+	return strings.ToUpper(level.String())
+}
+
+func levelColour(level logrus.Level) string {
+	switch level {
+	case logrus.DebugLevel:
+		return colourGrey
+	case logrus.InfoLevel:
+		return colourBlue
+	case logrus.WarnLevel:
+		return colourYellow
+	}
+	return colourRed
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConsoleLogger(colours bool) (*UPPLogger, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Formatter = newConsoleFormatter(testServiceName, ulog.keyConf, colours)
+	ulog.Out = out
+	return ulog, out
+}
+
+func TestConsoleFormatter(t *testing.T) {
+	ulog, out := newTestConsoleLogger(false)
+	logTime := time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+	ulog.WithMonitoringEvent(testEvent, testTID, testContentType).
+		WithUUID("0f2a4c54-1a14-11e9-8c2f-a0aeb4b2cd23").
+		WithField("status", 200).
+		WithField("path", "/content/1").
+		WithField("note", "with spaces").
+		WithField("tags", []string{"a", "b"}).
+		WithTime(logTime).
+		Warn(testMsg)
+
+	assert.Equal(t, "2020-03-04T05:06:07.000Z WARN  "+testMsg+
+		" transaction_id="+testTID+" uuid=0f2a4c54-1a14-11e9-8c2f-a0aeb4b2cd23 event="+testEvent+
+		" content_type="+testContentType+" monitoring_event=true note=\"with spaces\" path=/content/1 status=200 tags=[\"a\",\"b\"]\n",
+		out.String())
+}
+
+func TestConsoleFormatterAlignsLevels(t *testing.T) {
+	ulog, out := newTestConsoleLogger(false)
+	ulog.SetLevel(logrus.DebugLevel)
+
+	ulog.Debug("debug")
+	ulog.Info("info")
+	ulog.Error("error")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	for i, level := range []string{"DEBUG debug", "INFO  info", "ERROR error"} {
+		assert.Equal(t, level, lines[i][len("2020-03-04T05:06:07.000Z")+1:][:len(level)])
+	}
+}
+
+func TestConsoleFormatterStackTrace(t *testing.T) {
+	ulog, out := newTestConsoleLogger(false)
+
+	ulog.WithError(Errorf("failed: %w", errors.New(testErrMsg))).Error(testMsg)
+
+	lines := strings.Split(out.String(), "\n")
+	require.True(t, len(lines) > 3, "The stack trace should be logged on separate lines")
+	assert.Contains(t, lines[0], `error="failed: `+testErrMsg+`"`)
+	assert.NotContains(t, lines[0], "error_stack")
+	assert.Equal(t, "error_stack:", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "    github.com/Financial-Times/go-logger/v2.TestConsoleFormatterStackTrace "))
+}
+
+func TestConsoleFormatterColours(t *testing.T) {
+	ulog, out := newTestConsoleLogger(true)
+
+	ulog.WithTransactionID(testTID).WithField("foo", "bar").Error(testMsg)
+
+	assert.Contains(t, out.String(), colourRed+"ERROR"+colourReset)
+	assert.Contains(t, out.String(), colourCyan+"transaction_id="+testTID+colourReset)
+	assert.Contains(t, out.String(), colourBold+"foo"+colourReset+"=bar")
+}
+
+func TestConsoleFormatterSharesOptions(t *testing.T) {
+	ulog, out := newTestConsoleLogger(false)
+	redactor, err := NewRedactor(RedactionConfig{Keys: []string{"password"}})
+	require.NoError(t, err)
+	ulog.SetRedactor(redactor)
+	ulog.SetServiceMetadata(ServiceMetadata{Environment: "local"})
+
+	ulog.WithField("password", "secret").Info(testMsg)
+
+	assert.Contains(t, out.String(), "password="+redactedValue)
+	assert.Contains(t, out.String(), "environment=local")
+	assert.NotContains(t, out.String(), testServiceName)
+}
+
+func TestNewWithConsoleFormat(t *testing.T) {
+	ulog, err := New(testServiceName, WithFormat(FormatConsole))
+	require.NoError(t, err)
+	assert.IsType(t, &consoleFormatter{}, ulog.Formatter)
+
+	_, err = New(testServiceName, WithFormat("xml"))
+	assert.EqualError(t, err, `unknown log format "xml"`)
+}
+
+func TestNewFromEnvConsoleFormat(t *testing.T) {
+	ulog, err := newFromEnv(lookupEnvMap(map[string]string{EnvAppName: testServiceName, EnvLogFormat: "console"}))
+	require.NoError(t, err)
+	assert.IsType(t, &consoleFormatter{}, ulog.Formatter)
+}
@@ -0,0 +1,73 @@
+package logger
+
+import "context"
+
+type contextKey int
+
+const (
+	transactionIDContextKey contextKey = iota
+	uuidContextKey
+	fieldsContextKey
+)
+
+// ContextWithTransactionID returns a copy of ctx that carries the transaction ID.
+// The transaction ID is added to the log entries created with WithContext.
+func ContextWithTransactionID(ctx context.Context, tid string) context.Context {
+	return context.WithValue(ctx, transactionIDContextKey, tid)
+}
+
+// TransactionIDFromContext returns the transaction ID stored in ctx, if any.
+func TransactionIDFromContext(ctx context.Context) (string, bool) {
+	tid, ok := ctx.Value(transactionIDContextKey).(string)
+	return tid, ok
+}
+
+// ContextWithUUID returns a copy of ctx that carries the UUID.
+// The UUID is added to the log entries created with WithContext.
+func ContextWithUUID(ctx context.Context, uuid string) context.Context {
+	return context.WithValue(ctx, uuidContextKey, uuid)
+}
+
+// UUIDFromContext returns the UUID stored in ctx, if any.
+func UUIDFromContext(ctx context.Context) (string, bool) {
+	uuid, ok := ctx.Value(uuidContextKey).(string)
+	return uuid, ok
+}
+
+// ContextWithFields returns a copy of ctx that carries the fields.
+// The fields are merged with the ones already stored in ctx, the new values taking precedence.
+// The fields are added to the log entries created with WithContext.
+func ContextWithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	existing := FieldsFromContext(ctx)
+	merged := make(map[string]interface{}, len(existing)+len(fields))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsContextKey, merged)
+}
+
+// FieldsFromContext returns a copy of the fields stored in ctx.
+func FieldsFromContext(ctx context.Context) map[string]interface{} {
+	stored, _ := ctx.Value(fieldsContextKey).(map[string]interface{})
+	fields := make(map[string]interface{}, len(stored))
+	for k, v := range stored {
+		fields[k] = v
+	}
+	return fields
+}
+
+// contextFields collects all the logging fields stored in ctx using the key names from keyConf.
+// The transaction ID and the UUID take precedence over the fields with the same keys.
+func contextFields(ctx context.Context, keyConf *KeyNamesConfig) map[string]interface{} {
+	fields := FieldsFromContext(ctx)
+	if tid, ok := TransactionIDFromContext(ctx); ok {
+		fields[keyConf.KeyTransactionID] = tid
+	}
+	if uuid, ok := UUIDFromContext(ctx); ok {
+		fields[keyConf.KeyUUID] = uuid
+	}
+	return fields
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithTransactionID(t *testing.T) {
+	ctx := ContextWithTransactionID(context.Background(), "tid_test")
+
+	tid, found := TransactionIDFromContext(ctx)
+	assert.True(t, found)
+	assert.Equal(t, "tid_test", tid)
+
+	_, found = TransactionIDFromContext(context.Background())
+	assert.False(t, found)
+}
+
+func TestContextWithUUID(t *testing.T) {
+	ctx := ContextWithUUID(context.Background(), "test-uuid")
+
+	uuid, found := UUIDFromContext(ctx)
+	assert.True(t, found)
+	assert.Equal(t, "test-uuid", uuid)
+
+	_, found = UUIDFromContext(context.Background())
+	assert.False(t, found)
+}
+
+func TestContextWithFieldsMerges(t *testing.T) {
+	ctx := ContextWithFields(context.Background(), map[string]interface{}{"foo": "bar", "baz": "qux"})
+	ctx = ContextWithFields(ctx, map[string]interface{}{"foo": "overridden", "new": "field"})
+
+	fields := FieldsFromContext(ctx)
+	assert.Equal(t, map[string]interface{}{"foo": "overridden", "baz": "qux", "new": "field"}, fields)
+}
+
+func TestFieldsFromContextReturnsCopy(t *testing.T) {
+	ctx := ContextWithFields(context.Background(), map[string]interface{}{"foo": "bar"})
+
+	fields := FieldsFromContext(ctx)
+	fields["foo"] = "changed"
+
+	assert.Equal(t, "bar", FieldsFromContext(ctx)["foo"])
+	assert.Empty(t, FieldsFromContext(context.Background()))
+}
@@ -0,0 +1,224 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// formatState holds the fields and the keys of an entry being formatted.
+// It is pooled, so that formatting an entry does not allocate a new map and slice for each entry.
+type formatState struct {
+	data logrus.Fields
+	keys []string
+}
+
+var formatStatePool = sync.Pool{
+	New: func() interface{} {
+		return &formatState{data: make(logrus.Fields, 16), keys: make([]string, 0, 16)}
+	},
+}
+
+func getFormatState() *formatState {
+	return formatStatePool.Get().(*formatState)
+}
+
+func putFormatState(s *formatState) {
+	for k := range s.data {
+		delete(s.data, k)
+	}
+	s.keys = s.keys[:0]
+	formatStatePool.Put(s)
+}
+
+// jsonEncoder writes JSON values to a buffer. It has fast paths for the common field values
+// and falls back to encoding/json for the rest, producing the same output as json.Marshal.
+// Invalid UTF-8 is written as the escaped replacement character, as encoding/json v1 does, on every Go version.
+type jsonEncoder struct {
+	buf     *bytes.Buffer
+	scratch [64]byte
+}
+
+// writeValue writes the value as JSON. It returns the json.Marshal error for the values which can't be encoded.
+func (e *jsonEncoder) writeValue(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		e.buf.WriteString("null")
+	case string:
+		e.writeString(v)
+	case bool:
+		e.buf.Write(strconv.AppendBool(e.scratch[:0], v))
+	case int:
+		e.buf.Write(strconv.AppendInt(e.scratch[:0], int64(v), 10))
+	case int8:
+		e.buf.Write(strconv.AppendInt(e.scratch[:0], int64(v), 10))
+	case int16:
+		e.buf.Write(strconv.AppendInt(e.scratch[:0], int64(v), 10))
+	case int32:
+		e.buf.Write(strconv.AppendInt(e.scratch[:0], int64(v), 10))
+	case int64:
+		e.buf.Write(strconv.AppendInt(e.scratch[:0], v, 10))
+	case uint:
+		e.buf.Write(strconv.AppendUint(e.scratch[:0], uint64(v), 10))
+	case uint8:
+		e.buf.Write(strconv.AppendUint(e.scratch[:0], uint64(v), 10))
+	case uint16:
+		e.buf.Write(strconv.AppendUint(e.scratch[:0], uint64(v), 10))
+	case uint32:
+		e.buf.Write(strconv.AppendUint(e.scratch[:0], uint64(v), 10))
+	case uint64:
+		e.buf.Write(strconv.AppendUint(e.scratch[:0], v, 10))
+	case float32:
+		return e.writeFloat(float64(v), 32, value)
+	case float64:
+		return e.writeFloat(v, 64, value)
+	case time.Duration:
+		e.buf.Write(strconv.AppendInt(e.scratch[:0], int64(v), 10))
+	case time.Time:
+		if y := v.Year(); y < 0 || y >= 10000 {
+			return e.writeReflected(value)
+		}
+		e.buf.WriteByte('"')
+		e.buf.Write(v.AppendFormat(e.scratch[:0], time.RFC3339Nano))
+		e.buf.WriteByte('"')
+	case json.Marshaler:
+		return e.writeReflected(value)
+	case error:
+		e.writeString(v.Error())
+	case []byte:
+		e.writeBase64(v)
+	case []string:
+		if v == nil {
+			e.buf.WriteString("null")
+			return nil
+		}
+		e.buf.WriteByte('[')
+		for i, s := range v {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			e.writeString(s)
+		}
+		e.buf.WriteByte(']')
+	default:
+		return e.writeReflected(value)
+	}
+	return nil
+}
+
+func (e *jsonEncoder) writeReflected(value interface{}) error {
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	e.buf.Write(serialized)
+	return nil
+}
+
+// writeBase64 writes the bytes as a base64 string in chunks fitting the scratch buffer.
+// The chunks are multiples of 3 bytes, so that only the last one is padded.
+func (e *jsonEncoder) writeBase64(v []byte) {
+	const chunk = len(e.scratch) / 4 * 3
+	e.buf.WriteByte('"')
+	for len(v) > chunk {
+		base64.StdEncoding.Encode(e.scratch[:], v[:chunk])
+		e.buf.Write(e.scratch[:])
+		v = v[chunk:]
+	}
+	n := base64.StdEncoding.EncodedLen(len(v))
+	base64.StdEncoding.Encode(e.scratch[:n], v)
+	e.buf.Write(e.scratch[:n])
+	e.buf.WriteByte('"')
+}
+
+// writeFloat writes the float as encoding/json does.
+func (e *jsonEncoder) writeFloat(f float64, bits int, value interface{}) error {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		// json.Marshal returns the error for the unsupported values
+		return e.writeReflected(value)
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b := strconv.AppendFloat(e.scratch[:0], f, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	e.buf.Write(b)
+	return nil
+}
+
+// writeString writes the string as a JSON string, escaping it as json.Marshal does, including the HTML characters.
+func (e *jsonEncoder) writeString(s string) {
+	b := e.buf
+	b.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b.WriteString(s[start:i])
+			switch c {
+			case '"', '\\':
+				b.WriteByte('\\')
+				b.WriteByte(c)
+			case '\b':
+				b.WriteString(`\b`)
+			case '\f':
+				b.WriteString(`\f`)
+			case '\n':
+				b.WriteString(`\n`)
+			case '\r':
+				b.WriteString(`\r`)
+			case '\t':
+				b.WriteString(`\t`)
+			default:
+				b.WriteString(`\u00`)
+				b.WriteByte(hexDigits[c>>4])
+				b.WriteByte(hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteString(s[start:i])
+			b.WriteString(`\ufffd`)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 and U+2029 are escaped, as they are line terminators in JavaScript
+		if r == '\u2028' || r == '\u2029' {
+			b.WriteString(s[start:i])
+			b.WriteString(`\u202`)
+			b.WriteByte(hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b.WriteString(s[start:])
+	b.WriteByte('"')
+}
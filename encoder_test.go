@@ -0,0 +1,202 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMarshaler struct{}
+
+func (testMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`"marshaled"`), nil
+}
+
+func (testMarshaler) Error() string {
+	return "error"
+}
+
+func TestJSONEncoderMatchesJSONMarshal(t *testing.T) {
+	values := []interface{}{
+		nil,
+		"",
+		"plain",
+		"quote \" backslash \\ slash /",
+		"html <a href=\"x\">&amp;</a>",
+		"control \b\f\n\r\t\x00\x01\x1f\x7f",
+		"unicode ünïcödé 日本語 🙂",
+		"line separators \u2028 \u2029",
+		true,
+		false,
+		0,
+		-42,
+		int8(-8),
+		int16(16),
+		int32(-32),
+		int64(math.MaxInt64),
+		uint(42),
+		uint8(8),
+		uint16(16),
+		uint32(32),
+		uint64(math.MaxUint64),
+		0.0,
+		-0.5,
+		3.14159,
+		1e-7,
+		1e21,
+		123456789.123,
+		float32(0.1),
+		float32(1e-7),
+		float32(3e22),
+		1500 * time.Millisecond,
+		time.Date(2020, time.March, 4, 5, 6, 7, 890, time.UTC),
+		time.Date(2020, time.March, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600)),
+		[]byte("bytes \x00\xff"),
+		[]byte{},
+		bytes.Repeat([]byte("0123456789"), 20),
+		[]string{"a", "b <c>"},
+		[]string{},
+		[]string(nil),
+		map[string]interface{}{"nested": []int{1, 2}},
+		struct{ A string }{"a"},
+		net.ParseIP("127.0.0.1"),
+		testMarshaler{},
+		json.Number("12.5"),
+	}
+
+	for _, v := range values {
+		expected, err := json.Marshal(v)
+		assert.NoError(t, err)
+
+		b := new(bytes.Buffer)
+		enc := jsonEncoder{buf: b}
+		assert.NoError(t, enc.writeValue(v))
+		assert.Equal(t, string(expected), b.String(), "Unexpected encoding of %#v", v)
+	}
+}
+
+func TestJSONEncoderInvalidUTF8(t *testing.T) {
+	b := new(bytes.Buffer)
+	enc := jsonEncoder{buf: b}
+
+	assert.NoError(t, enc.writeValue("invalid utf-8 \xff\xfe"))
+	// encoding/json v1 escapes the replacement characters, while newer implementations may write them as they are
+	assert.Equal(t, `"invalid utf-8 \ufffd\ufffd"`, b.String())
+	var decoded string
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	assert.Equal(t, "invalid utf-8 \ufffd\ufffd", decoded)
+}
+
+func TestJSONEncoderErrors(t *testing.T) {
+	b := new(bytes.Buffer)
+	enc := jsonEncoder{buf: b}
+
+	assert.NoError(t, enc.writeValue(errors.New(testErrMsg)))
+	assert.Equal(t, `"`+testErrMsg+`"`, b.String(), "The errors should be encoded with their message")
+
+	for _, v := range []interface{}{math.NaN(), math.Inf(1), float32(math.Inf(-1)), make(chan int)} {
+		assert.Error(t, enc.writeValue(v), "Unexpected encoding of %#v", v)
+	}
+}
+
+func newBenchmarkEntry() *logrus.Entry {
+	ulog := NewUnstructuredLogger()
+	e := ulog.WithMonitoringEvent(testEvent, testTID, testContentType).
+		WithUUID("0f2a4c54-1a14-11e9-8c2f-a0aeb4b2cd23").
+		WithFields(map[string]interface{}{
+			"status":  200,
+			"ratio":   0.5,
+			"ok":      true,
+			"elapsed": 1500 * time.Millisecond,
+			"at":      time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC),
+			"payload": []byte("payload"),
+			"path":    "/content/0f2a4c54-1a14-11e9-8c2f-a0aeb4b2cd23",
+		})
+	e.Time = time.Now()
+	e.Message = testMsg
+	e.Level = logrus.InfoLevel
+	return e.Entry
+}
+
+// marshalEntryFields formats the entry as the formatter did before the JSON encoder,
+// by copying the fields to a new map and marshaling it with encoding/json.
+func marshalEntryFields(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(entry.Data)+4)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	data[DefaultKeyTime] = entry.Time.Format(timestampFormat)
+	data[DefaultKeyMsg] = entry.Message
+	data[DefaultKeyLogLevel] = entry.Level.String()
+	data[DefaultKeyServiceName] = testServiceName
+	serialized, err := json.Marshal(data)
+	return append(serialized, '\n'), err
+}
+
+func TestFtJSONFormatterAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("The allocations are not deterministic with the race detector")
+	}
+	f := newFTJSONFormatter(testServiceName, GetDefaultKeyNamesConfig())
+	entry := newBenchmarkEntry()
+	entry.Buffer = new(bytes.Buffer)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		entry.Buffer.Reset()
+		_, _ = f.Format(entry)
+	})
+	marshalAllocs := testing.AllocsPerRun(100, func() {
+		_, _ = marshalEntryFields(entry)
+	})
+
+	assert.True(t, allocs <= 4, "The formatter should allocate only for the time and the message and the service name fields, got %v allocations", allocs)
+	assert.True(t, allocs < marshalAllocs/5, "The formatter allocations %v should be far below json.Marshal ones %v", allocs, marshalAllocs)
+}
+
+func BenchmarkFtJSONFormatter(b *testing.B) {
+	f := newFTJSONFormatter(testServiceName, GetDefaultKeyNamesConfig())
+	entry := newBenchmarkEntry()
+	// logrus sets the buffer of the entries it writes
+	entry.Buffer = new(bytes.Buffer)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		entry.Buffer.Reset()
+		if _, err := f.Format(entry); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkJSONMarshalFields(b *testing.B) {
+	entry := newBenchmarkEntry()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := marshalEntryFields(entry); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUPPLoggerInfo(b *testing.B) {
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = new(bytes.Buffer)
+	e := ulog.WithTransactionID(testTID).WithUUID("0f2a4c54-1a14-11e9-8c2f-a0aeb4b2cd23").WithField("status", 200)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ulog.Out.(*bytes.Buffer).Reset()
+		e.Info(testMsg)
+	}
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"strconv"
 	"time"
 
@@ -47,6 +48,13 @@ func (entry *LogEntry) WithTransactionID(tid string) *LogEntry {
 	return &LogEntry{ulog: entry.ulog, Entry: entry.Entry.WithField(entry.ulog.keyConf.KeyTransactionID, tid)}
 }
 
+// WithContext returns new LogEntry with the fields stored in ctx in it.
+// These are the transaction ID, the UUID and the fields added with ContextWithTransactionID,
+// ContextWithUUID and ContextWithFields.
+func (entry *LogEntry) WithContext(ctx context.Context) *LogEntry {
+	return entry.WithFields(contextFields(ctx, entry.ulog.keyConf))
+}
+
 // WithError returns new LogEntry with error field in it.
 func (entry *LogEntry) WithError(err error) *LogEntry {
 	return &LogEntry{ulog: entry.ulog, Entry: entry.Entry.WithField(entry.ulog.keyConf.KeyError, err)}
@@ -64,6 +72,13 @@ func (entry *LogEntry) WithMonitoringEvent(eventName, tid, contentType string) *
 	return e.WithTransactionID(tid)
 }
 
+// WithMonitoringEventFields returns new LogEntry with the monitoring event fields in it.
+// Unlike WithMonitoringEvent, the event is validated, see SetMonitoringEventValidationHandler.
+func (entry *LogEntry) WithMonitoringEventFields(event MonitoringEventFields) *LogEntry {
+	entry.ulog.validateMonitoringEvent(event)
+	return entry.WithFields(event.fields(entry.ulog.keyConf))
+}
+
 // WithCategorisedEvent returns new LogEntry with categorised event fields in it.
 // The categorised event fields are event name, event category, event message.
 func (entry *LogEntry) WithCategorisedEvent(eventName, eventCategory, eventMsg, tid string) *LogEntry {
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -116,3 +117,18 @@ func TestLogEntryWithCategorisedEvent(t *testing.T) {
 	assert.Equal(t, "test-event-msg", hook.LastEntry().Data[DefaultKeyEventMsg])
 	assert.Equal(t, "test-tid", hook.LastEntry().Data[DefaultKeyTransactionID])
 }
+
+func TestLogEntryWithContext(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+
+	ctx := ContextWithTransactionID(context.Background(), "tid_test")
+	ctx = ContextWithFields(ctx, map[string]interface{}{DefaultKeyTransactionID: "tid_other", "foo": "bar"})
+	ulog.WithUUID("test-uuid").WithContext(ctx).Info("a info message")
+
+	assert.Len(t, hook.Entries, 1)
+	assert.Len(t, hook.LastEntry().Data, 3)
+	assert.Equal(t, "tid_test", hook.LastEntry().Data[DefaultKeyTransactionID])
+	assert.Equal(t, "test-uuid", hook.LastEntry().Data[DefaultKeyUUID])
+	assert.Equal(t, "bar", hook.LastEntry().Data["foo"])
+}
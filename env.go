@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The environment variables read by NewFromEnv.
+const (
+	EnvAppName     = "APP_NAME"
+	EnvServiceName = "SERVICE_NAME"
+	EnvLogLevel    = "LOG_LEVEL"
+	EnvLogFormat   = "LOG_FORMAT"
+	EnvNoColour    = "NO_COLOR"
+
+	// EnvKeyPrefix is the prefix of the variables overriding the key names, e.g. LOG_KEY_TRANSACTION_ID.
+	EnvKeyPrefix = "LOG_KEY_"
+)
+
+// The log formats supported by NewFromEnv.
+const (
+	// FormatJSON is the UPP JSON log format.
+	FormatJSON = "json"
+	// FormatUnstructured is the plain logrus log format.
+	FormatUnstructured = "unstructured"
+	// FormatLogfmt is the UPP log format with the same fields as FormatJSON in logfmt.
+	FormatLogfmt = "logfmt"
+	// FormatConsole is the human-readable UPP log format for local development.
+	FormatConsole = "console"
+)
+
+// NewFromEnv initializes UPPLogger from the environment variables:
+//
+//	APP_NAME or SERVICE_NAME  the service name, required for the UPP formats; APP_NAME takes precedence
+//	LOG_LEVEL                 the log level, e.g. debug or info; defaults to info
+//	LOG_FORMAT                json, logfmt, console or unstructured; defaults to json
+//	NO_COLOR                  disables the colours of the console format, if set to a non-empty value
+//	LOG_KEY_<KEY>             the name of a log key, e.g. LOG_KEY_TRANSACTION_ID=request_id
+//
+// The service metadata logged with every entry is detected as in DetectServiceMetadata.
+// The names of the key variables are the upper case default key names, see KeyNamesConfig,
+// except for LOG_KEY_IS_VALID and LOG_KEY_EVENT_NAME.
+// Unlike NewUPPLogger, it doesn't fall back to the defaults on invalid values,
+// but returns an error describing all of them.
+func NewFromEnv() (*UPPLogger, error) {
+	return newFromEnv(os.LookupEnv)
+}
+
+func newFromEnv(lookupEnv func(key string) (string, bool)) (*UPPLogger, error) {
+	var problems []string
+	getenv := func(key string) string {
+		value, _ := lookupEnv(key)
+		return strings.TrimSpace(value)
+	}
+
+	serviceName := getenv(EnvAppName)
+	if serviceName == "" {
+		serviceName = getenv(EnvServiceName)
+	}
+
+	level := logrus.InfoLevel
+	if value := getenv(EnvLogLevel); value != "" {
+		parsed, err := logrus.ParseLevel(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", EnvLogLevel, err))
+		}
+		level = parsed
+	}
+
+	format := strings.ToLower(getenv(EnvLogFormat))
+	switch format {
+	case "":
+		format = FormatJSON
+	case FormatJSON, FormatLogfmt, FormatConsole, FormatUnstructured:
+	default:
+		problems = append(problems, fmt.Sprintf("%s: unknown log format %q, expected %s, %s, %s or %s",
+			EnvLogFormat, format, FormatJSON, FormatLogfmt, FormatConsole, FormatUnstructured))
+	}
+	if format != FormatUnstructured && serviceName == "" {
+		problems = append(problems, fmt.Sprintf("%s or %s is required", EnvAppName, EnvServiceName))
+	}
+
+	var kconf KeyNamesConfig
+	for _, k := range keyNameEnvVars(&kconf) {
+		value, found := lookupEnv(k.env)
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("%s: empty key name", k.env))
+			continue
+		}
+		*k.key = value
+	}
+	keyConf := GetFullKeyNameConfig(kconf)
+	problems = append(problems, duplicateKeyNames(keyConf)...)
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid logger configuration: %s", strings.Join(problems, "; "))
+	}
+
+	if format == FormatUnstructured {
+		ulog := NewUnstructuredLogger()
+		ulog.keyConf = keyConf
+		ulog.SetLevel(level)
+		return ulog, nil
+	}
+	meta := detectServiceMetadata(lookupEnv, debug.ReadBuildInfo, os.Hostname)
+	return New(serviceName, WithLevel(level.String()), WithKeyNames(*keyConf), WithServiceMetadata(meta), WithFormat(format),
+		withLookupEnv(lookupEnv))
+}
+
+type keyNameEnvVar struct {
+	env string
+	key *string
+}
+
+// keyNameEnvVars returns the environment variables of the key names in conf in the order of KeyNamesConfig.
+func keyNameEnvVars(conf *KeyNamesConfig) []keyNameEnvVar {
+	return []keyNameEnvVar{
+		{EnvKeyPrefix + "LEVEL", &conf.KeyLogLevel},
+		{EnvKeyPrefix + "MSG", &conf.KeyMsg},
+		{EnvKeyPrefix + "ERROR", &conf.KeyError},
+		{EnvKeyPrefix + "TIME", &conf.KeyTime},
+		{EnvKeyPrefix + "SERVICE_NAME", &conf.KeyServiceName},
+		{EnvKeyPrefix + "TRANSACTION_ID", &conf.KeyTransactionID},
+		{EnvKeyPrefix + "UUID", &conf.KeyUUID},
+		{EnvKeyPrefix + "IS_VALID", &conf.KeyIsValid},
+		{EnvKeyPrefix + "EVENT_NAME", &conf.KeyEventName},
+		{EnvKeyPrefix + "MONITORING_EVENT", &conf.KeyMonitoringEvent},
+		{EnvKeyPrefix + "CONTENT_TYPE", &conf.KeyContentType},
+		{EnvKeyPrefix + "EVENT_CATEGORY", &conf.KeyEventCategory},
+		{EnvKeyPrefix + "EVENT_MSG", &conf.KeyEventMsg},
+		{EnvKeyPrefix + "PUBLISH_REFERENCE", &conf.KeyPublishReference},
+		{EnvKeyPrefix + "DURATION", &conf.KeyDuration},
+		{EnvKeyPrefix + "CALLER", &conf.KeyCaller},
+		{EnvKeyPrefix + "FUNCTION", &conf.KeyFunction},
+		{EnvKeyPrefix + "LOGGER_LEVEL", &conf.KeyLoggerLevel},
+		{EnvKeyPrefix + "ENVIRONMENT", &conf.KeyEnvironment},
+		{EnvKeyPrefix + "REGION", &conf.KeyRegion},
+		{EnvKeyPrefix + "VERSION", &conf.KeyVersion},
+		{EnvKeyPrefix + "GIT_COMMIT", &conf.KeyGitCommit},
+		{EnvKeyPrefix + "HOST", &conf.KeyHost},
+		{EnvKeyPrefix + "POD_NAME", &conf.KeyPodName},
+		{EnvKeyPrefix + "CLUSTER", &conf.KeyCluster},
+		{EnvKeyPrefix + "REASON", &conf.KeyReason},
+		{EnvKeyPrefix + "CONFIG", &conf.KeyConfig},
+		{EnvKeyPrefix + "DEPENDENCY", &conf.KeyDependency},
+		{EnvKeyPrefix + "CHECK", &conf.KeyCheck},
+		{EnvKeyPrefix + "HEALTHY", &conf.KeyHealthy},
+		{EnvKeyPrefix + "OUTPUT", &conf.KeyOutput},
+	}
+}
+
+// duplicateKeyNames describes the key names used for more than one key,
+// as the fields logged with them would overwrite each other.
+func duplicateKeyNames(conf *KeyNamesConfig) []string {
+	var problems []string
+	seen := map[string]string{}
+	for _, k := range keyNameEnvVars(conf) {
+		if other, found := seen[*k.key]; found {
+			problems = append(problems, fmt.Sprintf("%s: key name %q is already used by %s", k.env, *k.key, other))
+			continue
+		}
+		seen[*k.key] = k.env
+	}
+	return problems
+}
@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lookupEnvMap(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, found := env[key]
+		return value, found
+	}
+}
+
+func TestNewFromEnvDefaults(t *testing.T) {
+	ulog, err := newFromEnv(lookupEnvMap(map[string]string{EnvAppName: "test_service"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, logrus.InfoLevel, ulog.GetLevel())
+	assert.Equal(t, *GetDefaultKeyNamesConfig(), ulog.GetKeyNamesConfig())
+	f, ok := ulog.Formatter.(*ftJSONFormatter)
+	require.True(t, ok, "The logger should use the UPP JSON format")
+	assert.Equal(t, "test_service", f.serviceName)
+}
+
+func TestNewFromEnv(t *testing.T) {
+	ulog, err := newFromEnv(lookupEnvMap(map[string]string{
+		EnvServiceName:           "test_service",
+		EnvLogLevel:              " DEBUG ",
+		EnvLogFormat:             "JSON",
+		"LOG_KEY_TRANSACTION_ID": "request_id",
+		"LOG_KEY_MSG":            " message ",
+		"LOG_KEY_SOMETHING_ELSE": "ignored",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, logrus.DebugLevel, ulog.GetLevel())
+	conf := ulog.GetKeyNamesConfig()
+	assert.Equal(t, "request_id", conf.KeyTransactionID)
+	assert.Equal(t, "message", conf.KeyMsg)
+	assert.Equal(t, DefaultKeyUUID, conf.KeyUUID)
+}
+
+func TestNewFromEnvAppNamePrecedence(t *testing.T) {
+	ulog, err := newFromEnv(lookupEnvMap(map[string]string{EnvAppName: "app", EnvServiceName: "service"}))
+	require.NoError(t, err)
+	assert.Equal(t, "app", ulog.Formatter.(*ftJSONFormatter).serviceName)
+}
+
+func TestNewFromEnvUnstructured(t *testing.T) {
+	ulog, err := newFromEnv(lookupEnvMap(map[string]string{
+		EnvLogFormat:   FormatUnstructured,
+		EnvLogLevel:    "warning",
+		"LOG_KEY_UUID": "content_uuid",
+	}))
+	require.NoError(t, err)
+
+	_, ok := ulog.Formatter.(*ftJSONFormatter)
+	assert.False(t, ok, "The logger should use the logrus format")
+	assert.Equal(t, logrus.WarnLevel, ulog.GetLevel())
+	assert.Equal(t, "content_uuid", ulog.GetKeyNamesConfig().KeyUUID)
+}
+
+func TestNewFromEnvConsoleColours(t *testing.T) {
+	tests := map[string]struct {
+		env     map[string]string
+		colours bool
+	}{
+		"NO_COLOR unset":     {env: map[string]string{}, colours: true},
+		"NO_COLOR empty":     {env: map[string]string{EnvNoColour: ""}, colours: true},
+		"NO_COLOR non-empty": {env: map[string]string{EnvNoColour: "1"}, colours: false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.env[EnvAppName] = "test_service"
+			test.env[EnvLogFormat] = FormatConsole
+
+			ulog, err := newFromEnv(lookupEnvMap(test.env))
+			require.NoError(t, err)
+
+			f, ok := ulog.Formatter.(*consoleFormatter)
+			require.True(t, ok, "The logger should use the console format")
+			assert.Equal(t, test.colours, f.colours)
+		})
+	}
+}
+
+func TestNewFromEnvInvalid(t *testing.T) {
+	tests := map[string]struct {
+		env      map[string]string
+		expected []string
+	}{
+		"missing service name": {
+			env:      map[string]string{},
+			expected: []string{"APP_NAME or SERVICE_NAME is required"},
+		},
+		"invalid level": {
+			env:      map[string]string{EnvAppName: "test_service", EnvLogLevel: "verbose"},
+			expected: []string{"LOG_LEVEL: not a valid logrus Level: \"verbose\""},
+		},
+		"unknown format": {
+			env:      map[string]string{EnvAppName: "test_service", EnvLogFormat: "xml"},
+			expected: []string{"LOG_FORMAT: unknown log format \"xml\""},
+		},
+		"empty key name": {
+			env:      map[string]string{EnvAppName: "test_service", "LOG_KEY_UUID": " "},
+			expected: []string{"LOG_KEY_UUID: empty key name"},
+		},
+		"duplicate key name": {
+			env:      map[string]string{EnvAppName: "test_service", "LOG_KEY_UUID": DefaultKeyTransactionID},
+			expected: []string{"LOG_KEY_UUID: key name \"transaction_id\" is already used by LOG_KEY_TRANSACTION_ID"},
+		},
+		"duplicate key name with spaces": {
+			env:      map[string]string{EnvAppName: "test_service", "LOG_KEY_UUID": " " + DefaultKeyTransactionID + " "},
+			expected: []string{"LOG_KEY_UUID: key name \"transaction_id\" is already used by LOG_KEY_TRANSACTION_ID"},
+		},
+		"all the problems": {
+			env: map[string]string{EnvLogLevel: "verbose", "LOG_KEY_MSG": ""},
+			expected: []string{
+				"APP_NAME or SERVICE_NAME is required",
+				"LOG_LEVEL: not a valid logrus Level",
+				"LOG_KEY_MSG: empty key name",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ulog, err := newFromEnv(lookupEnvMap(test.env))
+			require.Error(t, err)
+			assert.Nil(t, ulog)
+			for _, expected := range test.expected {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
+func TestNewFromEnvReadsEnvironment(t *testing.T) {
+	t.Setenv(EnvAppName, "test_service")
+	t.Setenv(EnvLogLevel, "error")
+
+	ulog, err := NewFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, logrus.ErrorLevel, ulog.GetLevel())
+}
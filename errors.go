@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+const (
+	maxStackDepth      = 32
+	maxErrorChainDepth = 32
+
+	errorChainKeySuffix = "_chain"
+	errorTypesKeySuffix = "_types"
+	errorStackKeySuffix = "_stack"
+)
+
+// Errorf formats according to a format specifier and returns the string as a value that satisfies error,
+// exactly as fmt.Errorf does, including wrapping errors with the %w verb on Go 1.13 or later.
+// The returned error also records the stack trace of its creation, which is logged together with the error.
+func Errorf(format string, args ...interface{}) error {
+	pcs := make([]uintptr, maxStackDepth)
+	// skip runtime.Callers and Errorf
+	n := runtime.Callers(2, pcs)
+	return &stackError{err: fmt.Errorf(format, args...), stack: pcs[:n]}
+}
+
+// stackError is an error with the stack trace of its creation.
+type stackError struct {
+	err   error
+	stack []uintptr
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// StackTrace returns the program counters of the stack trace of the error creation.
+func (e *stackError) StackTrace() []uintptr {
+	return e.stack
+}
+
+// errorDetails describes an error with its chain of wrapped errors and its stack trace, if any.
+type errorDetails struct {
+	chain []string
+	types []string
+	stack []string
+}
+
+// describeError walks the chain of errors wrapped by err with errors.Unwrap and errors.Join semantics.
+// The stack trace is taken from the deepest error carrying one, i.e. the closest to the origin of the error.
+// The errors created with Errorf are transparent in the chain, as they have the same message as the error they wrap.
+func describeError(err error) errorDetails {
+	var details errorDetails
+	var stack []uintptr
+	var walk func(err error, depth int)
+	walk = func(err error, depth int) {
+		if err == nil || depth > maxErrorChainDepth {
+			return
+		}
+		if pcs := stackTrace(err); len(pcs) > 0 {
+			stack = pcs
+		}
+		if se, ok := err.(*stackError); ok {
+			walk(se.err, depth)
+			return
+		}
+
+		details.chain = append(details.chain, err.Error())
+		details.types = append(details.types, fmt.Sprintf("%T", err))
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap(), depth+1)
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner, depth+1)
+			}
+		}
+	}
+	walk(err, 0)
+
+	if len(stack) > 0 {
+		frames := runtime.CallersFrames(stack)
+		for {
+			frame, more := frames.Next()
+			details.stack = append(details.stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+			if !more {
+				break
+			}
+		}
+	}
+	return details
+}
+
+// stackTrace returns the stack trace carried by the error, either created by Errorf or
+// by a library following the github.com/pkg/errors convention of a StackTrace method
+// returning a slice of program counters.
+func stackTrace(err error) []uintptr {
+	if st, ok := err.(interface{ StackTrace() []uintptr }); ok {
+		return st.StackTrace()
+	}
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 0 || methodType.NumOut() != 1 ||
+		methodType.Out(0).Kind() != reflect.Slice || methodType.Out(0).Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	pcs := make([]uintptr, trace.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(trace.Index(i).Uint())
+	}
+	return pcs
+}
@@ -0,0 +1,23 @@
+//go:build go1.20
+// +build go1.20
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeErrorWrapped(t *testing.T) {
+	joined := errors.Join(errors.New("first"), errors.New("second"))
+	err := fmt.Errorf("publish failed: %w", joined)
+
+	details := describeError(err)
+
+	assert.Equal(t, []string{"publish failed: first\nsecond", "first\nsecond", "first", "second"}, details.chain)
+	assert.Equal(t, []string{"*fmt.wrapError", "*errors.joinError", "*errors.errorString", "*errors.errorString"}, details.types)
+	assert.Empty(t, details.stack)
+}
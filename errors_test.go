@@ -0,0 +1,82 @@
+//go:build go1.13
+// +build go1.13
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFrame uintptr
+
+type testStackTrace []testFrame
+
+// pkgError mimics the errors with stack trace of github.com/pkg/errors
+type pkgError struct {
+	msg   string
+	stack []uintptr
+}
+
+func (e *pkgError) Error() string {
+	return e.msg
+}
+
+func (e *pkgError) StackTrace() testStackTrace {
+	st := make(testStackTrace, len(e.stack))
+	for i, pc := range e.stack {
+		st[i] = testFrame(pc)
+	}
+	return st
+}
+
+func newPkgError(msg string) error {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(1, pcs)
+	return &pkgError{msg: msg, stack: pcs[:n]}
+}
+
+func TestErrorf(t *testing.T) {
+	cause := errors.New(testErrMsg)
+	err := Errorf("publish failed: %w", cause)
+
+	assert.Equal(t, "publish failed: "+testErrMsg, err.Error())
+	assert.True(t, errors.Is(err, cause))
+	assert.NotEmpty(t, stackTrace(err))
+}
+
+func TestDescribeErrorPlain(t *testing.T) {
+	details := describeError(errors.New(testErrMsg))
+
+	assert.Equal(t, []string{testErrMsg}, details.chain)
+	assert.Equal(t, []string{"*errors.errorString"}, details.types)
+	assert.Empty(t, details.stack)
+}
+
+func TestDescribeErrorWithStack(t *testing.T) {
+	err := fmt.Errorf("publish failed: %w", Errorf("mapping failed: %w", errors.New(testErrMsg)))
+
+	details := describeError(err)
+
+	assert.Equal(t, []string{"publish failed: mapping failed: " + testErrMsg, "mapping failed: " + testErrMsg, testErrMsg}, details.chain)
+	assert.Equal(t, []string{"*fmt.wrapError", "*fmt.wrapError", "*errors.errorString"}, details.types)
+	require.NotEmpty(t, details.stack)
+	assert.True(t, strings.HasPrefix(details.stack[0], loggerPackage+".TestDescribeErrorWithStack "), details.stack[0])
+}
+
+func TestDescribeErrorWithPkgErrorsStack(t *testing.T) {
+	err := fmt.Errorf("publish failed: %w", newPkgError(testErrMsg))
+
+	details := describeError(err)
+
+	assert.Equal(t, []string{"*fmt.wrapError", "*logger.pkgError"}, details.types)
+	require.NotEmpty(t, details.stack)
+	assert.True(t, strings.HasPrefix(details.stack[0], loggerPackage+".newPkgError "), details.stack[0])
+	assert.Contains(t, details.stack[1], loggerPackage+".TestDescribeErrorWithPkgErrorsStack ")
+}
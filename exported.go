@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"strconv"
 	"time"
 )
@@ -29,6 +30,13 @@ func (ulog *UPPLogger) WithTransactionID(tid string) *LogEntry {
 	return ulog.WithField(ulog.keyConf.KeyTransactionID, tid)
 }
 
+// WithContext creates an entry from the standard logger and adds the fields stored in ctx to it.
+// These are the transaction ID, the UUID and the fields added with ContextWithTransactionID,
+// ContextWithUUID and ContextWithFields.
+func (ulog *UPPLogger) WithContext(ctx context.Context) *LogEntry {
+	return ulog.WithFields(contextFields(ctx, ulog.keyConf))
+}
+
 // WithError creates an entry from the standard logger and adds an error field to it.
 func (ulog *UPPLogger) WithError(err error) *LogEntry {
 	return ulog.WithField(ulog.keyConf.KeyError, err)
@@ -61,6 +69,13 @@ func (ulog *UPPLogger) WithMonitoringEvent(eventName, tid, contentType string) *
 	return e.WithTransactionID(tid)
 }
 
+// WithMonitoringEventFields creates an entry from the standard logger and adds the monitoring event fields to it.
+// Unlike WithMonitoringEvent, the event is validated, see SetMonitoringEventValidationHandler.
+func (ulog *UPPLogger) WithMonitoringEventFields(event MonitoringEventFields) *LogEntry {
+	ulog.validateMonitoringEvent(event)
+	return ulog.WithFields(event.fields(ulog.keyConf))
+}
+
 // WithCategorisedEvent creates an entry from the standard logger and adds categorised event fields to it.
 // The added fields are "event", "event_category" and "event_msg".
 func (ulog *UPPLogger) WithCategorisedEvent(eventName, eventCategory, eventMsg, tid string) *LogEntry {
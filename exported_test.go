@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -117,3 +118,31 @@ func TestWithCategorisedEvent(t *testing.T) {
 	assert.Equal(t, "an-event-category", hook.LastEntry().Data[DefaultKeyEventCategory])
 	assert.Equal(t, "an-event-msg", hook.LastEntry().Data[DefaultKeyEventMsg])
 }
+
+func TestUPPLoggerWithContext(t *testing.T) {
+	conf := KeyNamesConfig{KeyTransactionID: "test-transaction-id-key"}
+	ulog := NewUPPInfoLogger("test_service", conf)
+	hook := test.NewLocal(ulog.Logger)
+
+	ctx := ContextWithTransactionID(context.Background(), "tid_test")
+	ctx = ContextWithUUID(ctx, "test-uuid")
+	ctx = ContextWithFields(ctx, map[string]interface{}{"foo": "bar"})
+	ulog.WithContext(ctx).Info("a info message")
+
+	assert.Len(t, hook.Entries, 1)
+	assert.Len(t, hook.LastEntry().Data, 3)
+	assert.Equal(t, "a info message", hook.LastEntry().Message)
+	assert.Equal(t, "tid_test", hook.LastEntry().Data[conf.KeyTransactionID])
+	assert.Equal(t, "test-uuid", hook.LastEntry().Data[DefaultKeyUUID])
+	assert.Equal(t, "bar", hook.LastEntry().Data["foo"])
+}
+
+func TestUPPLoggerWithEmptyContext(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.WithContext(context.Background()).Info("a info message")
+
+	assert.Len(t, hook.Entries, 1)
+	assert.Len(t, hook.LastEntry().Data, 0)
+}
@@ -1,39 +1,128 @@
 package logger
 
 import (
-	"encoding/json"
+	"bytes"
 	"errors"
-	"fmt"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
-const timestampFormat = time.RFC3339Nano
+const (
+	timestampFormat = time.RFC3339Nano
+
+	// encodingErrorPrefix marks the field values which could not be encoded, e.g. channels or NaN floats.
+	encodingErrorPrefix = "!ERROR: "
+)
 
 // ftJSONFormatter formats the logs in JSON format.
 // It always includes "msg", "level" and "service_name" fields for each log entry.
 // If there is time field in the log entry, ftJSONFormatter logs it in time.RFC3339Nano format.
+// If redactor is set, the sensitive values in the fields and the message are redacted before serialization.
+// The static fields are logged with each entry, unless the entry has fields with the same keys.
+// If now is set, it is used for the time of the entries instead of the time they were logged.
+// The fields are written in a deterministic order: the fields in fieldOrder first, then the rest sorted by key.
+// The field values which can't be encoded to JSON are replaced with an "!ERROR: ..." string describing the error
+// and counted in encodingErrors, so that the rest of the entry is still logged.
 type ftJSONFormatter struct {
-	serviceName string
-	keyConf     *KeyNamesConfig
+	// encodingErrors is accessed atomically and is first in the struct to be 64-bit aligned
+	encodingErrors uint64
+
+	serviceName  string
+	keyConf      *KeyNamesConfig
+	redactor     *Redactor
+	staticFields logrus.Fields
+	now          func() time.Time
+	fieldOrder   []string
 }
 
 func newFTJSONFormatter(serviceName string, keyConf *KeyNamesConfig) *ftJSONFormatter {
-	return &ftJSONFormatter{serviceName: serviceName, keyConf: keyConf}
+	return &ftJSONFormatter{serviceName: serviceName, keyConf: keyConf, fieldOrder: defaultFieldOrder(keyConf)}
+}
+
+// defaultFieldOrder returns the keys of the fields written first, so that the entries are easy to scan.
+func defaultFieldOrder(keyConf *KeyNamesConfig) []string {
+	return []string{keyConf.KeyTime, keyConf.KeyLogLevel, keyConf.KeyServiceName, keyConf.KeyTransactionID, keyConf.KeyMsg}
 }
 
 func (f *ftJSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	state := getFormatState()
+	defer putFormatState(state)
+	if err := f.fields(entry, state.data); err != nil {
+		return []byte{}, err
+	}
+
+	// logrus provides a pooled buffer, which is reused once the entry is written
+	b := entry.Buffer
+	if b == nil {
+		b = new(bytes.Buffer)
+	}
+	enc := jsonEncoder{buf: b}
+	b.WriteByte('{')
+	for i, k := range f.orderedKeys(state) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		enc.writeString(k)
+		b.WriteByte(':')
+		start := b.Len()
+		if err := enc.writeValue(state.data[k]); err != nil {
+			b.Truncate(start)
+			enc.writeString(encodingErrorPrefix + err.Error())
+			atomic.AddUint64(&f.encodingErrors, 1)
+		}
+	}
+	b.WriteString("}\n")
+	return b.Bytes(), nil
+}
+
+// orderedKeys returns the keys of the fields in the order they are written.
+func (f *ftJSONFormatter) orderedKeys(state *formatState) []string {
+	keys := state.keys[:0]
+	for i, k := range f.fieldOrder {
+		if _, found := state.data[k]; found && !containsKey(f.fieldOrder[:i], k) {
+			keys = append(keys, k)
+		}
+	}
+	first := len(keys)
+	for k := range state.data {
+		if !containsKey(f.fieldOrder, k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys[first:])
+	state.keys = keys
+	return keys
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// fields adds all the fields of the formatted entry to data, including the message, the time, the level and the service name.
+// It returns an error if the formatter is not initialised with the service name.
+// The formatters of the other UPP log formats share it, so that they log the same fields.
+func (f *ftJSONFormatter) fields(entry *logrus.Entry, data logrus.Fields) error {
 	if f.serviceName == "" {
-		return []byte{}, errors.New("UPP log formatter is not initialised with service name")
+		return errors.New("UPP log formatter is not initialised with service name")
 	}
 
-	data := make(logrus.Fields)
+	for k, v := range f.staticFields {
+		data[k] = v
+	}
 	for k, v := range entry.Data {
 		switch v := v.(type) {
 		case error:
 			// Otherwise errors are ignored by `encoding/json`
 			data[k] = v.Error()
+			addErrorDetails(data, k, v)
 		default:
 			if v != nil && v != "" {
 				data[k] = v
@@ -41,19 +130,54 @@ func (f *ftJSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 		}
 	}
 
+	msg := entry.Message
+	if f.redactor != nil {
+		f.redactor.redactFields(data)
+		msg = f.redactor.redactString(msg)
+	}
+
 	if _, found := data[f.keyConf.KeyTime]; !found {
-		data[f.keyConf.KeyTime] = entry.Time.Format(timestampFormat)
+		t := entry.Time
+		if f.now != nil {
+			t = f.now()
+		}
+		data[f.keyConf.KeyTime] = t.Format(timestampFormat)
 	}
 
-	if entry.Message != "" {
-		data[f.keyConf.KeyMsg] = entry.Message
+	if msg != "" {
+		data[f.keyConf.KeyMsg] = msg
 	}
-	data[f.keyConf.KeyLogLevel] = entry.Level.String()
+
+	data[f.keyConf.KeyLogLevel] = levelValue(entry.Level)
 	data[f.keyConf.KeyServiceName] = f.serviceName
+	return nil
+}
+
+// levelValues are the names of the levels as field values, converted to interface{} once rather than for every entry.
+var levelValues = func() []interface{} {
+	values := make([]interface{}, len(logrus.AllLevels))
+	for _, level := range logrus.AllLevels {
+		values[level] = level.String()
+	}
+	return values
+}()
 
-	serialized, err := json.Marshal(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal fields to JSON, %v", err)
+func levelValue(level logrus.Level) interface{} {
+	if int(level) < len(levelValues) {
+		return levelValues[level]
+	}
+	return level.String()
+}
+
+// addErrorDetails adds the chain of the wrapped errors and their types, when err wraps other errors,
+// and the stack trace, when err carries one, next to the error message logged with the given key.
+func addErrorDetails(data logrus.Fields, key string, err error) {
+	details := describeError(err)
+	if len(details.chain) > 1 {
+		data[key+errorChainKeySuffix] = details.chain
+		data[key+errorTypesKeySuffix] = details.types
+	}
+	if len(details.stack) > 0 {
+		data[key+errorStackKeySuffix] = details.stack
 	}
-	return append(serialized, '\n'), nil
 }
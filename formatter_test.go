@@ -1,14 +1,19 @@
 package logger
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
+	"math"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -202,3 +207,156 @@ func TestFtJSONFormatterEmptyVals(t *testing.T) {
 	assert.NotContains(t, logLine, "key-nil")
 	assert.NotContains(t, logLine, logrus.FieldKeyMsg)
 }
+
+func TestFtJSONFormatterWithWrappedError(t *testing.T) {
+	conf := KeyNamesConfig{KeyError: "test-err-key"}
+	f := newFTJSONFormatter(testServiceName, GetFullKeyNameConfig(conf))
+	ulog := NewUPPInfoLogger(testServiceName, conf)
+	e := ulog.WithTransactionID(testTID).WithError(Errorf("publish failed: %w", errors.New(testErrMsg)))
+	e.Time = time.Now()
+	e.Message = testMsg
+	e.Level = logrus.ErrorLevel
+
+	logLineBytes, err := f.Format(e.Entry)
+	assert.NoError(t, err)
+
+	var logLine map[string]interface{}
+	err = json.Unmarshal(logLineBytes, &logLine)
+	assert.NoError(t, err)
+	assert.Len(t, logLine, 9)
+
+	assert.Equal(t, "publish failed: "+testErrMsg, logLine[conf.KeyError])
+	assert.Equal(t, []interface{}{"publish failed: " + testErrMsg, testErrMsg}, logLine[conf.KeyError+"_chain"])
+	assert.Equal(t, []interface{}{"*fmt.wrapError", "*errors.errorString"}, logLine[conf.KeyError+"_types"])
+	assert.NotEmpty(t, logLine[conf.KeyError+"_stack"])
+}
+
+func TestFtJSONFormatterFieldOrder(t *testing.T) {
+	f := newFTJSONFormatter(testServiceName, GetDefaultKeyNamesConfig())
+	ulog := NewUnstructuredLogger()
+	e := ulog.WithMonitoringEvent(testEvent, testTID, testContentType).WithField("b", 2).WithField("a", "1")
+	e.Time = time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC)
+	e.Message = testMsg
+	e.Level = logrus.InfoLevel
+
+	for i := 0; i < 10; i++ {
+		logLineBytes, err := f.Format(e.Entry)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"time":"2020-03-04T05:06:07Z","level":"info","service_name":"`+testServiceName+`",`+
+			`"transaction_id":"`+testTID+`","msg":"`+testMsg+`","a":"1","b":2,`+
+			`"content_type":"`+testContentType+`","event":"`+testEvent+`","monitoring_event":"true"}`+"\n",
+			string(logLineBytes))
+	}
+}
+
+func TestFtJSONFormatterCustomFieldOrder(t *testing.T) {
+	ulog, err := New(testServiceName,
+		WithKeyNames(KeyNamesConfig{KeyMsg: "message"}),
+		WithClock(func() time.Time { return time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC) }),
+		WithFieldOrder("message", "level", "missing", "uuid"),
+	)
+	assert.NoError(t, err)
+	hook := &formattingHook{}
+	ulog.AddHook(hook)
+
+	ulog.WithUUID("test-uuid").WithTransactionID(testTID).Info(testMsg)
+
+	assert.Equal(t, `{"message":"`+testMsg+`","level":"info","uuid":"test-uuid",`+
+		`"service_name":"`+testServiceName+`","time":"2020-03-04T05:06:07Z","transaction_id":"`+testTID+`"}`+"\n",
+		hook.formatted)
+}
+
+func TestSetFieldOrderCopiesKeys(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithOutput(out))
+	require.NoError(t, err)
+	keys := []string{DefaultKeyMsg, DefaultKeyLogLevel}
+	ulog.SetFieldOrder(keys...)
+	keys[0] = DefaultKeyUUID
+
+	ulog.WithUUID("test-uuid").Info(testMsg)
+
+	assert.True(t, strings.HasPrefix(out.String(), `{"msg":"`+testMsg+`","level":"info",`), out.String())
+}
+
+// formattingHook records the last entry as formatted by the logger formatter.
+type formattingHook struct {
+	formatted string
+}
+
+func (h *formattingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *formattingHook) Fire(e *logrus.Entry) error {
+	formatted, err := e.Logger.Formatter.Format(e)
+	h.formatted = string(formatted)
+	return err
+}
+
+type cyclicNode struct {
+	Name string
+	Next *cyclicNode
+}
+
+func TestFtJSONFormatterUnencodableValues(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithOutput(out))
+	require.NoError(t, err)
+	cyclic := &cyclicNode{Name: "cyclic"}
+	cyclic.Next = cyclic
+
+	ulog.WithTransactionID(testTID).WithFields(map[string]interface{}{
+		"channel": make(chan int),
+		"func":    func() {},
+		"nan":     math.NaN(),
+		"nested":  map[string]interface{}{"inf": math.Inf(1)},
+		"cyclic":  cyclic,
+		"status":  200,
+	}).Info(testMsg)
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged), "The entry should be logged as valid JSON")
+	assert.Equal(t, testTID, logged[DefaultKeyTransactionID])
+	assert.Equal(t, testMsg, logged[DefaultKeyMsg])
+	assert.Equal(t, float64(200), logged["status"])
+	assert.Equal(t, "!ERROR: json: unsupported type: chan int", logged["channel"])
+	assert.Equal(t, "!ERROR: json: unsupported type: func()", logged["func"])
+	assert.Equal(t, "!ERROR: json: unsupported value: NaN", logged["nan"])
+	assert.Equal(t, "!ERROR: json: unsupported value: +Inf", logged["nested"])
+	assert.Contains(t, logged["cyclic"], "!ERROR: json: unsupported value: encountered a cycle")
+	assert.Equal(t, uint64(5), ulog.FieldEncodingErrors())
+
+	ulog.Info(testMsg)
+	assert.Equal(t, uint64(5), ulog.FieldEncodingErrors())
+}
+
+func TestFieldEncodingErrorsWithoutUPPFormatter(t *testing.T) {
+	ulog := NewUnstructuredLogger()
+	assert.Equal(t, uint64(0), ulog.FieldEncodingErrors())
+}
+
+func TestFieldEncodingErrorsTextFormats(t *testing.T) {
+	for _, format := range []string{FormatLogfmt, FormatConsole} {
+		t.Run(format, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ulog, err := New(testServiceName, WithOutput(out), WithFormat(format))
+			require.NoError(t, err)
+
+			ulog.WithField("channel", make(chan int)).WithField("func", func() {}).WithField("status", 200).Info(testMsg)
+
+			assert.Contains(t, out.String(), "=200")
+			assert.Equal(t, uint64(2), ulog.FieldEncodingErrors())
+		})
+	}
+}
+
+func TestFieldEncodingErrorsCountedForEachFormat(t *testing.T) {
+	ulog, err := New(testServiceName, WithOutput(ioutil.Discard))
+	require.NoError(t, err)
+	ulog.AddHook(&formattingHook{})
+
+	ulog.WithField("channel", make(chan int)).Info(testMsg)
+
+	assert.Equal(t, uint64(2), ulog.FieldEncodingErrors(), "The entry should be counted when formatted by the hook and when written")
+}
@@ -5,7 +5,7 @@ go 1.12
 require (
 	github.com/davecgh/go-spew v0.0.0-20170829195320-a47672248388 // indirect
 	github.com/onsi/ginkgo v1.9.0 // indirect
-	github.com/onsi/gomega v1.6.0 // indirect
+	github.com/onsi/gomega v1.6.0
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/sirupsen/logrus v1.0.5
 	github.com/stretchr/testify v0.0.0-20170809224252-890a5c3458b4
@@ -0,0 +1,176 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// levelState keeps track of the pending revert of a temporary log level.
+// If afterFunc is set, it is used to schedule the revert instead of time.AfterFunc.
+type levelState struct {
+	mu        sync.Mutex
+	timer     *time.Timer
+	revertTo  logrus.Level
+	revertAt  time.Time
+	afterFunc func(d time.Duration, f func()) *time.Timer
+}
+
+// LevelStatus describes the current log level of the logger and the pending revert of it, if any.
+type LevelStatus struct {
+	Level    string     `json:"level"`
+	RevertTo string     `json:"revert_to,omitempty"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+type levelRequest struct {
+	Level    string `json:"level"`
+	Duration string `json:"duration,omitempty"`
+}
+
+type levelErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// GetLevel returns the current log level of the logger.
+func (ulog *UPPLogger) GetLevel() logrus.Level {
+	return ulog.level()
+}
+
+// SetLevel sets the log level of the logger and cancels the pending revert of a temporary level, if any.
+func (ulog *UPPLogger) SetLevel(level logrus.Level) {
+	ulog.lvl.mu.Lock()
+	defer ulog.lvl.mu.Unlock()
+	ulog.stopLevelRevert()
+	ulog.Logger.SetLevel(level)
+}
+
+// SetTemporaryLevel sets the log level of the logger for the given duration.
+// After that the logger reverts to the level it had before the first of the consecutive temporary changes.
+func (ulog *UPPLogger) SetTemporaryLevel(level logrus.Level, d time.Duration) {
+	ulog.lvl.mu.Lock()
+	defer ulog.lvl.mu.Unlock()
+
+	revertTo := ulog.level()
+	if ulog.lvl.timer != nil {
+		revertTo = ulog.lvl.revertTo
+	}
+	ulog.stopLevelRevert()
+
+	ulog.Logger.SetLevel(level)
+	ulog.lvl.revertTo = revertTo
+	ulog.lvl.revertAt = time.Now().Add(d)
+
+	afterFunc := time.AfterFunc
+	if ulog.lvl.afterFunc != nil {
+		afterFunc = ulog.lvl.afterFunc
+	}
+	var timer *time.Timer
+	timer = afterFunc(d, func() {
+		if ulog.revertLevel(timer) {
+			ulog.logLevelChange(revertTo, "Temporary log level expired")
+		}
+	})
+	ulog.lvl.timer = timer
+}
+
+// revertLevel reverts the temporary log level set with the timer and reports whether it did,
+// i.e. whether the timer was not stopped or replaced in the meantime.
+func (ulog *UPPLogger) revertLevel(timer *time.Timer) bool {
+	ulog.lvl.mu.Lock()
+	defer ulog.lvl.mu.Unlock()
+	if ulog.lvl.timer != timer {
+		return false
+	}
+	ulog.lvl.timer = nil
+	ulog.Logger.SetLevel(ulog.lvl.revertTo)
+	return true
+}
+
+// logLevelChange logs the change of the log level to level. It must be called after the change, without the lock
+// of the level state held, as the hooks and the writers of the logger may use it as well.
+// The change is logged at info level, unless the new level suppresses the info entries. Then it is logged at the new level,
+// capped at error level, so that making the logger less verbose is logged as well.
+func (ulog *UPPLogger) logLevelChange(level logrus.Level, msg string) {
+	entry := ulog.WithField(ulog.keyConf.KeyLoggerLevel, level.String())
+	switch {
+	case level <= logrus.ErrorLevel:
+		entry.Error(msg)
+	case level == logrus.WarnLevel:
+		entry.Warn(msg)
+	default:
+		entry.Info(msg)
+	}
+}
+
+// LevelStatus returns the current log level of the logger and the pending revert of it, if any.
+func (ulog *UPPLogger) LevelStatus() LevelStatus {
+	ulog.lvl.mu.Lock()
+	defer ulog.lvl.mu.Unlock()
+
+	status := LevelStatus{Level: ulog.level().String()}
+	if ulog.lvl.timer != nil {
+		revertAt := ulog.lvl.revertAt
+		status.RevertTo = ulog.lvl.revertTo.String()
+		status.RevertAt = &revertAt
+	}
+	return status
+}
+
+// stopLevelRevert must be called with the lock of the level state held.
+func (ulog *UPPLogger) stopLevelRevert() {
+	if ulog.lvl.timer != nil {
+		ulog.lvl.timer.Stop()
+		ulog.lvl.timer = nil
+	}
+}
+
+// LevelHandler returns an HTTP handler exposing the log level of the logger.
+// GET responds with the current level, e.g. {"level":"info"}.
+// PUT changes the level with a body like {"level":"debug"}. When a duration is included,
+// e.g. {"level":"debug","duration":"10m"}, the logger reverts to the previous level after it.
+// The level changes are logged at info level, or at the new level if it suppresses the info entries.
+func (ulog *UPPLogger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeLevelResponse(w, http.StatusOK, ulog.LevelStatus())
+		case http.MethodPut:
+			var req levelRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeLevelResponse(w, http.StatusBadRequest, levelErrorResponse{fmt.Sprintf("invalid request body: %v", err)})
+				return
+			}
+			level, err := logrus.ParseLevel(req.Level)
+			if err != nil {
+				writeLevelResponse(w, http.StatusBadRequest, levelErrorResponse{err.Error()})
+				return
+			}
+			if req.Duration == "" {
+				ulog.SetLevel(level)
+			} else {
+				d, err := time.ParseDuration(req.Duration)
+				if err != nil || d <= 0 {
+					writeLevelResponse(w, http.StatusBadRequest, levelErrorResponse{fmt.Sprintf("invalid duration: %q", req.Duration)})
+					return
+				}
+				ulog.SetTemporaryLevel(level, d)
+			}
+			ulog.logLevelChange(level, "Log level changed")
+			writeLevelResponse(w, http.StatusOK, ulog.LevelStatus())
+		default:
+			w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+			writeLevelResponse(w, http.StatusMethodNotAllowed, levelErrorResponse{"method not allowed"})
+		}
+	})
+}
+
+func writeLevelResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,184 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualTimers records the level reverts scheduled by SetTemporaryLevel, so that the tests can fire them.
+type manualTimers struct {
+	durations []time.Duration
+	reverts   []func()
+}
+
+func (m *manualTimers) afterFunc(d time.Duration, f func()) *time.Timer {
+	m.durations = append(m.durations, d)
+	m.reverts = append(m.reverts, f)
+	// The returned timer only needs to be stoppable
+	return time.AfterFunc(time.Hour, func() {})
+}
+
+func newManualTimersLogger(level string) (*UPPLogger, *manualTimers, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPLogger("test_service", level)
+	ulog.Out = out
+	timers := new(manualTimers)
+	ulog.lvl.afterFunc = timers.afterFunc
+	return ulog, timers, out
+}
+
+func TestLevelHandlerGet(t *testing.T) {
+	ulog := NewUPPLogger("test_service", "warning")
+	rec := httptest.NewRecorder()
+
+	ulog.LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/__log-level", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"level":"warning"}`, rec.Body.String())
+}
+
+func TestLevelHandlerPut(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	rec := httptest.NewRecorder()
+
+	ulog.LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/__log-level", strings.NewReader(`{"level":"debug"}`)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"level":"debug"}`, rec.Body.String())
+	assert.Equal(t, logrus.DebugLevel, ulog.GetLevel())
+}
+
+func TestLevelHandlerPutLogsLessVerboseLevel(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger("test_service")
+	ulog.Out = out
+	rec := httptest.NewRecorder()
+
+	ulog.LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/__log-level", strings.NewReader(`{"level":"error"}`)))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, logrus.ErrorLevel, ulog.GetLevel())
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, "Log level changed", logged[DefaultKeyMsg])
+	assert.Equal(t, "error", logged[DefaultKeyLoggerLevel])
+	assert.Equal(t, "error", logged[DefaultKeyLogLevel], "The change should be logged at the new level not to be suppressed by it")
+}
+
+func TestLevelHandlerPutLogsWithKeyNames(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger("test_service", KeyNamesConfig{KeyLoggerLevel: "logger_level"})
+	ulog.Out = out
+
+	ulog.LevelHandler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/__log-level", strings.NewReader(`{"level":"debug"}`)))
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, "debug", logged["logger_level"])
+	assert.Equal(t, "info", logged[DefaultKeyLogLevel])
+}
+
+func TestLevelHandlerPutWithDuration(t *testing.T) {
+	ulog, timers, out := newManualTimersLogger("warning")
+	rec := httptest.NewRecorder()
+
+	body := `{"level":"debug","duration":"50ms"}`
+	ulog.LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/__log-level", strings.NewReader(body)))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status LevelStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, "debug", status.Level)
+	assert.Equal(t, "warning", status.RevertTo)
+	require.NotNil(t, status.RevertAt)
+	assert.WithinDuration(t, time.Now().Add(50*time.Millisecond), *status.RevertAt, time.Second)
+	assert.Equal(t, logrus.DebugLevel, ulog.GetLevel())
+	assert.Equal(t, []time.Duration{50 * time.Millisecond}, timers.durations)
+
+	timers.reverts[0]()
+	assert.Equal(t, logrus.WarnLevel, ulog.GetLevel())
+	assert.Equal(t, LevelStatus{Level: "warning"}, ulog.LevelStatus())
+	assert.Contains(t, out.String(), `"msg":"Temporary log level expired"`, "The revert should be logged at the new level not to be suppressed by it")
+}
+
+// levelStatusHook reads the level status of the logger whenever an entry is logged.
+type levelStatusHook struct {
+	ulog *UPPLogger
+}
+
+func (h *levelStatusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *levelStatusHook) Fire(*logrus.Entry) error {
+	h.ulog.LevelStatus()
+	return nil
+}
+
+func TestLevelRevertLogsWithoutLock(t *testing.T) {
+	ulog, timers, out := newManualTimersLogger("info")
+	ulog.AddHook(&levelStatusHook{ulog})
+	ulog.SetTemporaryLevel(logrus.DebugLevel, time.Minute)
+
+	reverted := make(chan struct{})
+	go func() {
+		timers.reverts[0]()
+		close(reverted)
+	}()
+	select {
+	case <-reverted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The revert should not log while holding the lock of the level state")
+	}
+	assert.Equal(t, logrus.InfoLevel, ulog.GetLevel())
+	assert.Contains(t, out.String(), `"msg":"Temporary log level expired"`)
+}
+
+func TestLevelHandlerBadRequests(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+
+	for _, body := range []string{`not json`, `{"level":"verbose"}`, `{"level":"debug","duration":"forever"}`, `{"level":"debug","duration":"-1m"}`} {
+		rec := httptest.NewRecorder()
+		ulog.LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/__log-level", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		assert.Contains(t, rec.Body.String(), "message", body)
+	}
+	assert.Equal(t, logrus.InfoLevel, ulog.GetLevel())
+}
+
+func TestLevelHandlerMethodNotAllowed(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	rec := httptest.NewRecorder()
+
+	ulog.LevelHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/__log-level", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, PUT", rec.Header().Get("Allow"))
+}
+
+func TestSetLevelCancelsTemporaryLevel(t *testing.T) {
+	ulog, timers, _ := newManualTimersLogger("info")
+
+	ulog.SetTemporaryLevel(logrus.DebugLevel, 20*time.Millisecond)
+	ulog.SetTemporaryLevel(logrus.WarnLevel, 20*time.Millisecond)
+	assert.Equal(t, "info", ulog.LevelStatus().RevertTo)
+
+	ulog.SetLevel(logrus.ErrorLevel)
+	// The reverts of the cancelled temporary levels may still fire if their timers were already expiring
+	for _, revert := range timers.reverts {
+		revert()
+	}
+
+	assert.Equal(t, logrus.ErrorLevel, ulog.GetLevel())
+	assert.Nil(t, ulog.LevelStatus().RevertAt)
+}
@@ -0,0 +1,108 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// The event names of the service lifecycle events, logged in the event name field.
+// They are stable, so that the dashboards and alerts can rely on them.
+const (
+	ServiceStartedEvent        = "service_started"
+	ServiceStoppingEvent       = "service_stopping"
+	ServiceStoppedEvent        = "service_stopped"
+	ConfigLoadedEvent          = "config_loaded"
+	DependencyUnavailableEvent = "dependency_unavailable"
+	HealthStatusChangedEvent   = "health_status_changed"
+)
+
+var (
+	defaultRedactorOnce sync.Once
+	defaultRedactor     *Redactor
+)
+
+// LogServiceStartedEvent logs service started event with level INFO.
+func (ulog *UPPLogger) LogServiceStartedEvent(port int) {
+	fields := map[string]interface{}{
+		ulog.keyConf.KeyEventName: ServiceStartedEvent,
+	}
+	ulog.WithFields(fields).Infof("Service running on port [%d]", port)
+}
+
+// LogServiceStoppingEvent logs service stopping event with level INFO, e.g. when the service receives SIGTERM.
+func (ulog *UPPLogger) LogServiceStoppingEvent(reason string) {
+	fields := map[string]interface{}{
+		ulog.keyConf.KeyEventName: ServiceStoppingEvent,
+		ulog.keyConf.KeyReason:    reason,
+	}
+	ulog.WithFields(fields).Infof("Service stopping: %s", reason)
+}
+
+// LogServiceStoppedEvent logs service stopped event with level INFO and the uptime of the service in milliseconds.
+func (ulog *UPPLogger) LogServiceStoppedEvent(uptime time.Duration) {
+	fields := map[string]interface{}{
+		ulog.keyConf.KeyEventName: ServiceStoppedEvent,
+		ulog.keyConf.KeyDuration:  durationMillis(uptime),
+	}
+	ulog.WithFields(fields).Infof("Service stopped after %s", uptime)
+}
+
+// LogConfigLoadedEvent logs config loaded event with level INFO and the configuration of the service.
+// The sensitive values in config are redacted with the redactor of the logger, see SetRedactor,
+// or with DefaultRedactionConfig if the logger has none.
+func (ulog *UPPLogger) LogConfigLoadedEvent(config interface{}) {
+	if f, ok := ulog.uppFormatter(); !ok || f.redactor == nil {
+		config = getDefaultRedactor().redact(ulog.keyConf.KeyConfig, config)
+	}
+	fields := map[string]interface{}{
+		ulog.keyConf.KeyEventName: ConfigLoadedEvent,
+		ulog.keyConf.KeyConfig:    config,
+	}
+	ulog.WithFields(fields).Info("Configuration loaded")
+}
+
+// LogDependencyUnavailableEvent logs dependency unavailable event with level ERROR,
+// e.g. when a database or a downstream service can't be reached.
+func (ulog *UPPLogger) LogDependencyUnavailableEvent(name string, err error) {
+	fields := map[string]interface{}{
+		ulog.keyConf.KeyEventName:  DependencyUnavailableEvent,
+		ulog.keyConf.KeyDependency: name,
+		ulog.keyConf.KeyError:      err,
+	}
+	ulog.WithFields(fields).Errorf("Dependency %s is unavailable", name)
+}
+
+// LogHealthStatusChangedEvent logs health status changed event when the health check changes its status,
+// with level INFO when the check becomes healthy and with level WARNING when it becomes unhealthy.
+// The output is the health check output describing the status.
+func (ulog *UPPLogger) LogHealthStatusChangedEvent(checkName string, healthy bool, output string) {
+	fields := map[string]interface{}{
+		ulog.keyConf.KeyEventName: HealthStatusChangedEvent,
+		ulog.keyConf.KeyCheck:     checkName,
+		ulog.keyConf.KeyHealthy:   healthy,
+		ulog.keyConf.KeyOutput:    output,
+	}
+	entry := ulog.WithFields(fields)
+	if healthy {
+		entry.Infof("Health check %s is healthy", checkName)
+		return
+	}
+	entry.Warnf("Health check %s is unhealthy", checkName)
+}
+
+// getDefaultRedactor returns the redactor for DefaultRedactionConfig. If the config is invalid,
+// it returns a redactor for its keys only, which redacts the values of the sensitive fields still.
+func getDefaultRedactor() *Redactor {
+	defaultRedactorOnce.Do(func() {
+		defaultRedactor = newDefaultRedactor(DefaultRedactionConfig())
+	})
+	return defaultRedactor
+}
+
+func newDefaultRedactor(conf RedactionConfig) *Redactor {
+	redactor, err := NewRedactor(conf)
+	if err != nil {
+		redactor, _ = NewRedactor(RedactionConfig{Keys: conf.Keys, Mode: conf.Mode})
+	}
+	return redactor
+}
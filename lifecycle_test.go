@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Port        int    `json:"port"`
+	DatabaseURL string `json:"databaseURL"`
+	APIKey      string `json:"apiKey"`
+}
+
+func TestLogServiceStartedEvent(t *testing.T) {
+	ulog := NewUPPInfoLogger(testServiceName)
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.LogServiceStartedEvent(8080)
+
+	assert.Equal(t, logrus.InfoLevel, hook.LastEntry().Level)
+	assert.Equal(t, ServiceStartedEvent, hook.LastEntry().Data[DefaultKeyEventName])
+	assert.Equal(t, "Service running on port [8080]", hook.LastEntry().Message)
+}
+
+func TestLogServiceStoppingEvent(t *testing.T) {
+	ulog := NewUPPInfoLogger(testServiceName, KeyNamesConfig{KeyEventName: "event_name"})
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.LogServiceStoppingEvent("SIGTERM received")
+
+	assert.Equal(t, logrus.InfoLevel, hook.LastEntry().Level)
+	assert.Equal(t, ServiceStoppingEvent, hook.LastEntry().Data["event_name"])
+	assert.Equal(t, "SIGTERM received", hook.LastEntry().Data[DefaultKeyReason])
+	assert.Equal(t, "Service stopping: SIGTERM received", hook.LastEntry().Message)
+}
+
+func TestLogServiceStoppedEvent(t *testing.T) {
+	ulog := NewUPPInfoLogger(testServiceName)
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.LogServiceStoppedEvent(90 * time.Minute)
+
+	assert.Equal(t, logrus.InfoLevel, hook.LastEntry().Level)
+	assert.Equal(t, ServiceStoppedEvent, hook.LastEntry().Data[DefaultKeyEventName])
+	assert.Equal(t, int64(5400000), hook.LastEntry().Data[DefaultKeyDuration])
+	assert.Equal(t, "Service stopped after 1h30m0s", hook.LastEntry().Message)
+}
+
+func TestLogConfigLoadedEvent(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = out
+
+	ulog.LogConfigLoadedEvent(testConfig{Port: 8080, DatabaseURL: "mongodb://localhost:27017", APIKey: "secret-key"})
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, ConfigLoadedEvent, logged[DefaultKeyEventName])
+	assert.Equal(t, "Configuration loaded", logged[DefaultKeyMsg])
+	assert.Equal(t, map[string]interface{}{
+		"port":        float64(8080),
+		"databaseURL": "mongodb://localhost:27017",
+		"apiKey":      redactedValue,
+	}, logged[DefaultKeyConfig])
+}
+
+func TestLogConfigLoadedEventWithRedactor(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = out
+	redactor, err := NewRedactor(RedactionConfig{Keys: []string{"databaseURL"}})
+	require.NoError(t, err)
+	ulog.SetRedactor(redactor)
+
+	ulog.LogConfigLoadedEvent(testConfig{Port: 8080, DatabaseURL: "mongodb://localhost:27017", APIKey: "secret-key"})
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, map[string]interface{}{
+		"port":        float64(8080),
+		"databaseURL": redactedValue,
+		"apiKey":      "secret-key",
+	}, logged[DefaultKeyConfig], "The config should be redacted with the redactor of the logger")
+}
+
+func TestLogDependencyUnavailableEvent(t *testing.T) {
+	ulog := NewUPPInfoLogger(testServiceName)
+	hook := test.NewLocal(ulog.Logger)
+	err := errors.New("connection refused")
+
+	ulog.LogDependencyUnavailableEvent("neo4j", err)
+
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(t, DependencyUnavailableEvent, hook.LastEntry().Data[DefaultKeyEventName])
+	assert.Equal(t, "neo4j", hook.LastEntry().Data[DefaultKeyDependency])
+	assert.Equal(t, err, hook.LastEntry().Data[DefaultKeyError])
+	assert.Equal(t, "Dependency neo4j is unavailable", hook.LastEntry().Message)
+}
+
+func TestLogHealthStatusChangedEvent(t *testing.T) {
+	ulog := NewUPPInfoLogger(testServiceName)
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.LogHealthStatusChangedEvent("kafka-connectivity", false, "kafka is unreachable")
+
+	assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+	assert.Equal(t, HealthStatusChangedEvent, hook.LastEntry().Data[DefaultKeyEventName])
+	assert.Equal(t, "kafka-connectivity", hook.LastEntry().Data[DefaultKeyCheck])
+	assert.Equal(t, false, hook.LastEntry().Data[DefaultKeyHealthy])
+	assert.Equal(t, "kafka is unreachable", hook.LastEntry().Data[DefaultKeyOutput])
+	assert.Equal(t, "Health check kafka-connectivity is unhealthy", hook.LastEntry().Message)
+
+	ulog.LogHealthStatusChangedEvent("kafka-connectivity", true, "OK")
+
+	assert.Equal(t, logrus.InfoLevel, hook.LastEntry().Level)
+	assert.Equal(t, true, hook.LastEntry().Data[DefaultKeyHealthy])
+	assert.Equal(t, "Health check kafka-connectivity is healthy", hook.LastEntry().Message)
+}
+
+func TestLifecycleEventsWithKeyNames(t *testing.T) {
+	ulog := NewUPPInfoLogger(testServiceName, KeyNamesConfig{
+		KeyReason:     "stop_reason",
+		KeyDependency: "dependency_name",
+		KeyCheck:      "check_name",
+		KeyHealthy:    "is_healthy",
+		KeyOutput:     "check_output",
+	})
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.LogServiceStoppingEvent("SIGTERM received")
+	assert.Equal(t, "SIGTERM received", hook.LastEntry().Data["stop_reason"])
+
+	ulog.LogDependencyUnavailableEvent("neo4j", errors.New("connection refused"))
+	assert.Equal(t, "neo4j", hook.LastEntry().Data["dependency_name"])
+
+	ulog.LogHealthStatusChangedEvent("kafka-connectivity", true, "OK")
+	assert.Equal(t, logrus.Fields{
+		DefaultKeyEventName: HealthStatusChangedEvent,
+		"check_name":        "kafka-connectivity",
+		"is_healthy":        true,
+		"check_output":      "OK",
+	}, hook.LastEntry().Data)
+}
+
+func TestLogConfigLoadedEventWithConfigKey(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName, KeyNamesConfig{KeyConfig: "configuration"})
+	ulog.Out = out
+
+	ulog.LogConfigLoadedEvent(testConfig{Port: 8080, APIKey: "secret-key"})
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, redactedValue, logged["configuration"].(map[string]interface{})["apiKey"])
+}
+
+func TestNewDefaultRedactorInvalidConfig(t *testing.T) {
+	conf := DefaultRedactionConfig()
+	conf.ValuePatterns = append(conf.ValuePatterns, "(")
+
+	redactor := newDefaultRedactor(conf)
+
+	require.NotNil(t, redactor, "An invalid config should not prevent the redaction")
+	assert.Equal(t, redactedValue, redactor.redact("authorization", "Basic dXNlcjpwYXNz"))
+}
@@ -0,0 +1,327 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The logging methods of LogEntry and UPPLogger below shadow the ones of logrus, so that the entries are sampled
+// and their caller is captured before logrus fires the hooks and writes them.
+// The entries logged through the embedded logrus types are neither sampled nor have their caller reported.
+
+// Debug logs the entry at debug level, unless it is sampled out.
+func (entry *LogEntry) Debug(args ...interface{}) {
+	if entry.enabled(logrus.DebugLevel) {
+		entry.log(logrus.DebugLevel, fmt.Sprint(args...))
+	}
+}
+
+// Print logs the entry at info level, unless it is sampled out.
+func (entry *LogEntry) Print(args ...interface{}) {
+	entry.Info(args...)
+}
+
+// Info logs the entry at info level, unless it is sampled out.
+func (entry *LogEntry) Info(args ...interface{}) {
+	if entry.enabled(logrus.InfoLevel) {
+		entry.log(logrus.InfoLevel, fmt.Sprint(args...))
+	}
+}
+
+// Warn logs the entry at warn level, unless it is sampled out.
+func (entry *LogEntry) Warn(args ...interface{}) {
+	if entry.enabled(logrus.WarnLevel) {
+		entry.log(logrus.WarnLevel, fmt.Sprint(args...))
+	}
+}
+
+// Warning logs the entry at warn level, unless it is sampled out.
+func (entry *LogEntry) Warning(args ...interface{}) {
+	entry.Warn(args...)
+}
+
+// Error logs the entry at error level.
+func (entry *LogEntry) Error(args ...interface{}) {
+	if entry.enabled(logrus.ErrorLevel) {
+		entry.log(logrus.ErrorLevel, fmt.Sprint(args...))
+	}
+}
+
+// Fatal logs the entry at fatal level and exits, as logrus does, even if the fatal level is disabled.
+func (entry *LogEntry) Fatal(args ...interface{}) {
+	entry.log(logrus.FatalLevel, fmt.Sprint(args...))
+}
+
+// Panic logs the entry at panic level and panics, as logrus does.
+func (entry *LogEntry) Panic(args ...interface{}) {
+	entry.log(logrus.PanicLevel, fmt.Sprint(args...))
+}
+
+// Debugf logs the entry at debug level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (entry *LogEntry) Debugf(format string, args ...interface{}) {
+	if entry.enabled(logrus.DebugLevel) {
+		entry.log(logrus.DebugLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+// Infof logs the entry at info level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (entry *LogEntry) Infof(format string, args ...interface{}) {
+	if entry.enabled(logrus.InfoLevel) {
+		entry.log(logrus.InfoLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+// Printf logs the entry at info level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (entry *LogEntry) Printf(format string, args ...interface{}) {
+	entry.Infof(format, args...)
+}
+
+// Warnf logs the entry at warn level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (entry *LogEntry) Warnf(format string, args ...interface{}) {
+	if entry.enabled(logrus.WarnLevel) {
+		entry.log(logrus.WarnLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+// Warningf logs the entry at warn level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (entry *LogEntry) Warningf(format string, args ...interface{}) {
+	entry.Warnf(format, args...)
+}
+
+// Errorf logs the entry at error level with the message formatted as fmt.Sprintf does.
+func (entry *LogEntry) Errorf(format string, args ...interface{}) {
+	if entry.enabled(logrus.ErrorLevel) {
+		entry.log(logrus.ErrorLevel, fmt.Sprintf(format, args...))
+	}
+}
+
+// Fatalf logs the entry at fatal level with the message formatted as fmt.Sprintf does and exits.
+func (entry *LogEntry) Fatalf(format string, args ...interface{}) {
+	entry.log(logrus.FatalLevel, fmt.Sprintf(format, args...))
+}
+
+// Panicf logs the entry at panic level with the message formatted as fmt.Sprintf does and panics.
+func (entry *LogEntry) Panicf(format string, args ...interface{}) {
+	entry.log(logrus.PanicLevel, fmt.Sprintf(format, args...))
+}
+
+// Debugln logs the entry at debug level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (entry *LogEntry) Debugln(args ...interface{}) {
+	if entry.enabled(logrus.DebugLevel) {
+		entry.log(logrus.DebugLevel, sprintln(args...))
+	}
+}
+
+// Infoln logs the entry at info level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (entry *LogEntry) Infoln(args ...interface{}) {
+	if entry.enabled(logrus.InfoLevel) {
+		entry.log(logrus.InfoLevel, sprintln(args...))
+	}
+}
+
+// Println logs the entry at info level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (entry *LogEntry) Println(args ...interface{}) {
+	entry.Infoln(args...)
+}
+
+// Warnln logs the entry at warn level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (entry *LogEntry) Warnln(args ...interface{}) {
+	if entry.enabled(logrus.WarnLevel) {
+		entry.log(logrus.WarnLevel, sprintln(args...))
+	}
+}
+
+// Warningln logs the entry at warn level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (entry *LogEntry) Warningln(args ...interface{}) {
+	entry.Warnln(args...)
+}
+
+// Errorln logs the entry at error level with the message formatted as fmt.Sprintln does.
+func (entry *LogEntry) Errorln(args ...interface{}) {
+	if entry.enabled(logrus.ErrorLevel) {
+		entry.log(logrus.ErrorLevel, sprintln(args...))
+	}
+}
+
+// Fatalln logs the entry at fatal level with the message formatted as fmt.Sprintln does and exits.
+func (entry *LogEntry) Fatalln(args ...interface{}) {
+	entry.log(logrus.FatalLevel, sprintln(args...))
+}
+
+// Panicln logs the entry at panic level with the message formatted as fmt.Sprintln does and panics.
+func (entry *LogEntry) Panicln(args ...interface{}) {
+	entry.log(logrus.PanicLevel, sprintln(args...))
+}
+
+func (entry *LogEntry) enabled(level logrus.Level) bool {
+	return entry.ulog.level() >= level
+}
+
+// log logs the entry with the message, unless it is sampled out.
+func (entry *LogEntry) log(level logrus.Level, msg string) {
+	if s := entry.ulog.sampler; s != nil && !s.sample(level, msg, entry.Data, entry.ulog.keyConf) {
+		return
+	}
+	e := entry.Entry
+	if entry.ulog.reportCaller {
+		e = entry.withCaller()
+	}
+	switch level {
+	case logrus.DebugLevel:
+		e.Debug(msg)
+	case logrus.InfoLevel:
+		e.Info(msg)
+	case logrus.WarnLevel:
+		e.Warn(msg)
+	case logrus.ErrorLevel:
+		e.Error(msg)
+	case logrus.FatalLevel:
+		e.Fatal(msg)
+	default:
+		e.Panic(msg)
+	}
+}
+
+// withCaller returns the entry with the caller (file:line) and the function that logged it.
+// The caller is captured here rather than in the formatter, as the entries may be formatted by the hooks as well.
+func (entry *LogEntry) withCaller() *logrus.Entry {
+	frame, found := findCaller()
+	if !found {
+		return entry.Entry
+	}
+	return entry.Entry.WithFields(logrus.Fields{
+		entry.ulog.keyConf.KeyCaller:   fmt.Sprintf("%s:%d", frame.File, frame.Line),
+		entry.ulog.keyConf.KeyFunction: frame.Function,
+	})
+}
+
+// sprintln formats the arguments as logrus does, i.e. as fmt.Sprintln without the trailing new line.
+func sprintln(args ...interface{}) string {
+	msg := fmt.Sprintln(args...)
+	return msg[:len(msg)-1]
+}
+
+func (ulog *UPPLogger) newEntry() *LogEntry {
+	return &LogEntry{ulog, logrus.NewEntry(ulog.Logger)}
+}
+
+// Debug logs a message at debug level, unless it is sampled out.
+func (ulog *UPPLogger) Debug(args ...interface{}) {
+	ulog.newEntry().Debug(args...)
+}
+
+// Print logs a message at info level, unless it is sampled out.
+func (ulog *UPPLogger) Print(args ...interface{}) {
+	ulog.newEntry().Print(args...)
+}
+
+// Info logs a message at info level, unless it is sampled out.
+func (ulog *UPPLogger) Info(args ...interface{}) {
+	ulog.newEntry().Info(args...)
+}
+
+// Warn logs a message at warn level, unless it is sampled out.
+func (ulog *UPPLogger) Warn(args ...interface{}) {
+	ulog.newEntry().Warn(args...)
+}
+
+// Warning logs a message at warn level, unless it is sampled out.
+func (ulog *UPPLogger) Warning(args ...interface{}) {
+	ulog.newEntry().Warning(args...)
+}
+
+// Error logs a message at error level.
+func (ulog *UPPLogger) Error(args ...interface{}) {
+	ulog.newEntry().Error(args...)
+}
+
+// Fatal logs a message at fatal level and exits.
+func (ulog *UPPLogger) Fatal(args ...interface{}) {
+	ulog.newEntry().Fatal(args...)
+}
+
+// Panic logs a message at panic level and panics.
+func (ulog *UPPLogger) Panic(args ...interface{}) {
+	ulog.newEntry().Panic(args...)
+}
+
+// Debugf logs a message at debug level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (ulog *UPPLogger) Debugf(format string, args ...interface{}) {
+	ulog.newEntry().Debugf(format, args...)
+}
+
+// Infof logs a message at info level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (ulog *UPPLogger) Infof(format string, args ...interface{}) {
+	ulog.newEntry().Infof(format, args...)
+}
+
+// Printf logs a message at info level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (ulog *UPPLogger) Printf(format string, args ...interface{}) {
+	ulog.newEntry().Printf(format, args...)
+}
+
+// Warnf logs a message at warn level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (ulog *UPPLogger) Warnf(format string, args ...interface{}) {
+	ulog.newEntry().Warnf(format, args...)
+}
+
+// Warningf logs a message at warn level with the message formatted as fmt.Sprintf does, unless it is sampled out.
+func (ulog *UPPLogger) Warningf(format string, args ...interface{}) {
+	ulog.newEntry().Warningf(format, args...)
+}
+
+// Errorf logs a message at error level with the message formatted as fmt.Sprintf does.
+func (ulog *UPPLogger) Errorf(format string, args ...interface{}) {
+	ulog.newEntry().Errorf(format, args...)
+}
+
+// Fatalf logs a message at fatal level with the message formatted as fmt.Sprintf does and exits.
+func (ulog *UPPLogger) Fatalf(format string, args ...interface{}) {
+	ulog.newEntry().Fatalf(format, args...)
+}
+
+// Panicf logs a message at panic level with the message formatted as fmt.Sprintf does and panics.
+func (ulog *UPPLogger) Panicf(format string, args ...interface{}) {
+	ulog.newEntry().Panicf(format, args...)
+}
+
+// Debugln logs a message at debug level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (ulog *UPPLogger) Debugln(args ...interface{}) {
+	ulog.newEntry().Debugln(args...)
+}
+
+// Infoln logs a message at info level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (ulog *UPPLogger) Infoln(args ...interface{}) {
+	ulog.newEntry().Infoln(args...)
+}
+
+// Println logs a message at info level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (ulog *UPPLogger) Println(args ...interface{}) {
+	ulog.newEntry().Println(args...)
+}
+
+// Warnln logs a message at warn level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (ulog *UPPLogger) Warnln(args ...interface{}) {
+	ulog.newEntry().Warnln(args...)
+}
+
+// Warningln logs a message at warn level with the message formatted as fmt.Sprintln does, unless it is sampled out.
+func (ulog *UPPLogger) Warningln(args ...interface{}) {
+	ulog.newEntry().Warningln(args...)
+}
+
+// Errorln logs a message at error level with the message formatted as fmt.Sprintln does.
+func (ulog *UPPLogger) Errorln(args ...interface{}) {
+	ulog.newEntry().Errorln(args...)
+}
+
+// Fatalln logs a message at fatal level with the message formatted as fmt.Sprintln does and exits.
+func (ulog *UPPLogger) Fatalln(args ...interface{}) {
+	ulog.newEntry().Fatalln(args...)
+}
+
+// Panicln logs a message at panic level with the message formatted as fmt.Sprintln does and panics.
+func (ulog *UPPLogger) Panicln(args ...interface{}) {
+	ulog.newEntry().Panicln(args...)
+}
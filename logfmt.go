@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sirupsen/logrus"
+)
+
+// logfmtFormatter formats the logs in logfmt format, e.g.
+//
+//	time=2020-03-04T05:06:07Z level=info service_name=test-service transaction_id=tid_test msg="Content published"
+//
+// It logs the same fields as ftJSONFormatter with the same key names and in the same order, sharing its options.
+type logfmtFormatter struct {
+	*ftJSONFormatter
+}
+
+func newLogfmtFormatter(serviceName string, keyConf *KeyNamesConfig) *logfmtFormatter {
+	return &logfmtFormatter{ftJSONFormatter: newFTJSONFormatter(serviceName, keyConf)}
+}
+
+func (f *logfmtFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	state := getFormatState()
+	defer putFormatState(state)
+	if err := f.fields(entry, state.data); err != nil {
+		return []byte{}, err
+	}
+	data := state.data
+
+	keys := f.orderedKeys(state)
+
+	b := new(bytes.Buffer)
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(logfmtKey(k))
+		b.WriteByte('=')
+		b.WriteString(f.textValue(data[k], true))
+	}
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+// logfmtKey replaces the characters not allowed in logfmt keys with underscores.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// textValue returns the value as it is for the simple strings and numbers, quoted and escaped for the strings
+// with spaces, quotes, equal signs or non-printable characters and in JSON for the composite values, e.g. maps and slices.
+// The composite values are quoted as well, unless quoteComposite is false.
+// The values which can't be encoded to JSON are formatted with %+v instead and counted in encodingErrors.
+func (f *ftJSONFormatter) textValue(value interface{}, quoteComposite bool) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return fmt.Sprint(v)
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		serialized, err := json.Marshal(value)
+		if err != nil {
+			s = fmt.Sprintf("%+v", value)
+			atomic.AddUint64(&f.encodingErrors, 1)
+		} else {
+			s = string(serialized)
+		}
+		if !quoteComposite {
+			return s
+		}
+	}
+	if needsQuoting(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogfmtFormatter(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithFormat(FormatLogfmt), WithOutput(out))
+	require.NoError(t, err)
+	logTime := time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+	ulog.WithMonitoringEvent(testEvent, testTID, testContentType).
+		WithField("status", 200).
+		WithField("quote", `say "hi"`).
+		WithField("multiline", "a\nb").
+		WithField("path", "/content/1").
+		WithField("tags", []string{"a", "b"}).
+		WithField("key with=spaces", "x").
+		WithTime(logTime).
+		Info(testMsg)
+
+	assert.Equal(t, `time=2020-03-04T05:06:07Z level=info service_name=`+testServiceName+` transaction_id=`+testTID+` msg="`+testMsg+`"`+
+		` content_type=`+testContentType+` event=`+testEvent+` key_with_spaces=x monitoring_event=true multiline="a\nb"`+
+		` path=/content/1 quote="say \"hi\"" status=200 tags="[\"a\",\"b\"]"`+"\n",
+		out.String())
+}
+
+func TestLogfmtFormatterCustomKeys(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName,
+		WithFormat(FormatLogfmt),
+		WithOutput(out),
+		WithKeyNames(KeyNamesConfig{KeyServiceName: "app", KeyMsg: "message", KeyTransactionID: "request_id", KeyError: "err"}),
+		WithClock(testClock),
+	)
+	require.NoError(t, err)
+
+	ulog.WithTransactionID(testTID).WithError(errors.New(testErrMsg)).Error("failed")
+
+	assert.Equal(t, `time=2020-03-04T05:06:07Z level=error app=`+testServiceName+` request_id=`+testTID+` message=failed err="`+testErrMsg+`"`+"\n",
+		out.String())
+}
+
+func TestLogfmtFormatterEmptyValue(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithFormat(FormatLogfmt), WithOutput(out), WithClock(testClock))
+	require.NoError(t, err)
+
+	ulog.WithField("count", 0).WithField("ok", false).Info("")
+
+	assert.Equal(t, `time=2020-03-04T05:06:07Z level=info service_name=`+testServiceName+" count=0 ok=false\n", out.String())
+}
+
+func TestLogfmtFormatterSharesOptions(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithFormat(FormatLogfmt), WithOutput(out))
+	require.NoError(t, err)
+	ulog.SetReportCaller(true)
+
+	ulog.Info(testMsg)
+
+	assert.Contains(t, out.String(), " caller=")
+	assert.Contains(t, out.String(), "logfmt_test.go:")
+	assert.Contains(t, out.String(), " function=github.com/Financial-Times/go-logger/v2.TestLogfmtFormatterSharesOptions")
+}
+
+func TestNewFromEnvLogfmtFormat(t *testing.T) {
+	ulog, err := newFromEnv(lookupEnvMap(map[string]string{EnvAppName: testServiceName, EnvLogFormat: "logfmt"}))
+	require.NoError(t, err)
+	assert.IsType(t, &logfmtFormatter{}, ulog.Formatter)
+}
+
+func TestLogfmtKey(t *testing.T) {
+	assert.Equal(t, "transaction_id", logfmtKey("transaction_id"))
+	assert.Equal(t, "a_b_c_d", logfmtKey("a b=c\"d"))
+	assert.Equal(t, "_", logfmtKey(""))
+}
@@ -1,17 +1,21 @@
 package logger
 
 import (
-	"github.com/sirupsen/logrus"
-)
+	"sync/atomic"
 
-const (
-	serviceStartedEvent = "service_started"
+	"github.com/sirupsen/logrus"
 )
 
 // UPPLogger wraps logrus logger providing the same functionality as logrus with a few UPP specifics.
 type UPPLogger struct {
 	*logrus.Logger
 	keyConf *KeyNamesConfig
+	lvl     levelState
+	sampler *Sampler
+
+	reportCaller bool
+
+	onInvalidEvent func(err error)
 }
 
 // NewUPPLogger initializes UPP logger with structured logging format.
@@ -21,17 +25,19 @@ func NewUPPLogger(serviceName string, logLevel string, kconf ...KeyNamesConfig)
 		keyConf = GetFullKeyNameConfig(kconf[0])
 	}
 
-	logrusLog := logrus.New()
-	formatter := newFTJSONFormatter(serviceName, keyConf)
-	logrusLog.Formatter = formatter
-
+	ulog := newUPPLogger(serviceName, keyConf)
 	parsedLogLevel, err := logrus.ParseLevel(logLevel)
 	if err != nil {
-		logrusLog.WithField("logLevel", logLevel).WithError(err).Error("Incorrect log level. Using INFO instead.")
+		ulog.WithField("logLevel", logLevel).WithError(err).Error("Incorrect log level. Using INFO instead.")
 		parsedLogLevel = logrus.InfoLevel
 	}
-	logrusLog.SetLevel(parsedLogLevel)
+	ulog.Logger.SetLevel(parsedLogLevel)
+	return ulog
+}
 
+func newUPPLogger(serviceName string, keyConf *KeyNamesConfig) *UPPLogger {
+	logrusLog := logrus.New()
+	logrusLog.Formatter = newFTJSONFormatter(serviceName, keyConf)
 	return &UPPLogger{Logger: logrusLog, keyConf: keyConf}
 }
 
@@ -45,10 +51,67 @@ func NewUnstructuredLogger() *UPPLogger {
 	return &UPPLogger{Logger: logrus.New(), keyConf: GetDefaultKeyNamesConfig()}
 }
 
-// LogServiceStartedEvent logs service started event with level INFO.
-func (ulog *UPPLogger) LogServiceStartedEvent(port int) {
-	fields := map[string]interface{}{
-		ulog.keyConf.KeyEventName: serviceStartedEvent,
+// GetKeyNamesConfig returns a copy of the key names configuration used by the logger.
+func (ulog *UPPLogger) GetKeyNamesConfig() KeyNamesConfig {
+	return *ulog.keyConf
+}
+
+// SetReportCaller enables or disables logging the caller (file:line) and the function that logged each entry.
+// It should be called before the logger is used.
+func (ulog *UPPLogger) SetReportCaller(reportCaller bool) {
+	ulog.reportCaller = reportCaller
+}
+
+// SetRedactor sets the redactor of the sensitive values logged by the logger, see DefaultRedactionConfig.
+// It has effect only on loggers with the UPP log format and should be called before the logger is used.
+func (ulog *UPPLogger) SetRedactor(redactor *Redactor) {
+	if f, ok := ulog.uppFormatter(); ok {
+		f.redactor = redactor
 	}
-	ulog.WithFields(fields).Infof("Service running on port [%d]", port)
+}
+
+// SetSampler sets the sampler of the entries logged by the logger.
+// The sampled out entries are neither passed to the logger hooks nor written.
+// It should be called before the logger is used.
+func (ulog *UPPLogger) SetSampler(sampler *Sampler) {
+	ulog.sampler = sampler
+}
+
+// SetFieldOrder sets the keys of the fields written first, in this order. The rest of the fields follow sorted by key.
+// By default, the time, the level, the service name, the transaction ID and the message come first.
+// It has effect only on loggers with the UPP log format and should be called before the logger is used.
+func (ulog *UPPLogger) SetFieldOrder(keys ...string) {
+	if f, ok := ulog.uppFormatter(); ok {
+		f.fieldOrder = append([]string(nil), keys...)
+	}
+}
+
+// FieldEncodingErrors returns the number of field values which could not be encoded to JSON, e.g. channels or NaN floats.
+// Such values are logged as an "!ERROR: ..." string describing the error instead of failing the whole entry,
+// or formatted with %+v in the logfmt and console formats. The values are counted every time the entry is formatted,
+// so an entry formatted by the hooks as well, e.g. to send it elsewhere, is counted once for each of them.
+func (ulog *UPPLogger) FieldEncodingErrors() uint64 {
+	if f, ok := ulog.uppFormatter(); ok {
+		return atomic.LoadUint64(&f.encodingErrors)
+	}
+	return 0
+}
+
+// uppFormatter returns the formatter of the UPP log formats, which holds their options, if the logger uses one.
+func (ulog *UPPLogger) uppFormatter() (*ftJSONFormatter, bool) {
+	switch f := ulog.Formatter.(type) {
+	case *ftJSONFormatter:
+		return f, true
+	case *consoleFormatter:
+		return f.ftJSONFormatter, true
+	case *logfmtFormatter:
+		return f.ftJSONFormatter, true
+	}
+	return nil, false
+}
+
+// level returns the current log level of the logger.
+// The level is read atomically as logrus sets it atomically in SetLevel.
+func (ulog *UPPLogger) level() logrus.Level {
+	return logrus.Level(atomic.LoadUint32((*uint32)(&ulog.Logger.Level)))
 }
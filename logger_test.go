@@ -60,3 +60,14 @@ func TestNewUnstructuredLogger(t *testing.T) {
 	ulog := NewUnstructuredLogger()
 	assert.Equal(t, ulog.keyConf, GetDefaultKeyNamesConfig())
 }
+
+func TestGetKeyNamesConfig(t *testing.T) {
+	conf := KeyNamesConfig{KeyTransactionID: "test-transaction-id-key"}
+	ulog := NewUPPInfoLogger("test_service", conf)
+
+	actual := ulog.GetKeyNamesConfig()
+	assert.Equal(t, *GetFullKeyNameConfig(conf), actual)
+
+	actual.KeyUUID = "changed"
+	assert.Equal(t, DefaultKeyUUID, ulog.keyConf.KeyUUID)
+}
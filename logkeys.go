@@ -18,6 +18,29 @@ const (
 	DefaultKeyContentType     = "content_type"
 	DefaultKeyEventCategory   = "event_category"
 	DefaultKeyEventMsg        = "event_msg"
+
+	DefaultKeyPublishReference = "publish_reference"
+	DefaultKeyDuration         = "duration"
+
+	DefaultKeyCaller   = "caller"
+	DefaultKeyFunction = "function"
+
+	DefaultKeyLoggerLevel = "logLevel"
+
+	DefaultKeyEnvironment = "environment"
+	DefaultKeyRegion      = "region"
+	DefaultKeyVersion     = "version"
+	DefaultKeyGitCommit   = "git_commit"
+	DefaultKeyHost        = "host"
+	DefaultKeyPodName     = "pod_name"
+	DefaultKeyCluster     = "cluster"
+
+	DefaultKeyReason     = "reason"
+	DefaultKeyConfig     = "config"
+	DefaultKeyDependency = "dependency"
+	DefaultKeyCheck      = "check"
+	DefaultKeyHealthy    = "healthy"
+	DefaultKeyOutput     = "output"
 )
 
 type KeyNamesConfig struct {
@@ -36,23 +59,64 @@ type KeyNamesConfig struct {
 	KeyContentType     string
 	KeyEventCategory   string
 	KeyEventMsg        string
+
+	KeyPublishReference string
+	KeyDuration         string
+
+	KeyCaller   string
+	KeyFunction string
+
+	KeyLoggerLevel string
+
+	KeyEnvironment string
+	KeyRegion      string
+	KeyVersion     string
+	KeyGitCommit   string
+	KeyHost        string
+	KeyPodName     string
+	KeyCluster     string
+
+	KeyReason     string
+	KeyConfig     string
+	KeyDependency string
+	KeyCheck      string
+	KeyHealthy    string
+	KeyOutput     string
 }
 
 func GetDefaultKeyNamesConfig() *KeyNamesConfig {
 	return &KeyNamesConfig{
-		KeyLogLevel:        DefaultKeyLogLevel,
-		KeyMsg:             DefaultKeyMsg,
-		KeyError:           DefaultKeyError,
-		KeyTime:            DefaultKeyTime,
-		KeyServiceName:     DefaultKeyServiceName,
-		KeyTransactionID:   DefaultKeyTransactionID,
-		KeyUUID:            DefaultKeyUUID,
-		KeyIsValid:         DefaultKeyIsValid,
-		KeyEventName:       DefaultKeyEventName,
-		KeyMonitoringEvent: DefaultKeyMonitoringEvent,
-		KeyContentType:     DefaultKeyContentType,
-		KeyEventCategory:   DefaultKeyEventCategory,
-		KeyEventMsg:        DefaultKeyEventMsg,
+		KeyLogLevel:         DefaultKeyLogLevel,
+		KeyMsg:              DefaultKeyMsg,
+		KeyError:            DefaultKeyError,
+		KeyTime:             DefaultKeyTime,
+		KeyServiceName:      DefaultKeyServiceName,
+		KeyTransactionID:    DefaultKeyTransactionID,
+		KeyUUID:             DefaultKeyUUID,
+		KeyIsValid:          DefaultKeyIsValid,
+		KeyEventName:        DefaultKeyEventName,
+		KeyMonitoringEvent:  DefaultKeyMonitoringEvent,
+		KeyContentType:      DefaultKeyContentType,
+		KeyEventCategory:    DefaultKeyEventCategory,
+		KeyEventMsg:         DefaultKeyEventMsg,
+		KeyPublishReference: DefaultKeyPublishReference,
+		KeyDuration:         DefaultKeyDuration,
+		KeyCaller:           DefaultKeyCaller,
+		KeyFunction:         DefaultKeyFunction,
+		KeyLoggerLevel:      DefaultKeyLoggerLevel,
+		KeyEnvironment:      DefaultKeyEnvironment,
+		KeyRegion:           DefaultKeyRegion,
+		KeyVersion:          DefaultKeyVersion,
+		KeyGitCommit:        DefaultKeyGitCommit,
+		KeyHost:             DefaultKeyHost,
+		KeyPodName:          DefaultKeyPodName,
+		KeyCluster:          DefaultKeyCluster,
+		KeyReason:           DefaultKeyReason,
+		KeyConfig:           DefaultKeyConfig,
+		KeyDependency:       DefaultKeyDependency,
+		KeyCheck:            DefaultKeyCheck,
+		KeyHealthy:          DefaultKeyHealthy,
+		KeyOutput:           DefaultKeyOutput,
 	}
 }
 
@@ -100,5 +164,59 @@ func GetFullKeyNameConfig(conf KeyNamesConfig) *KeyNamesConfig {
 	if conf.KeyEventMsg == "" {
 		conf.KeyEventMsg = defaultConfig.KeyEventMsg
 	}
+	if conf.KeyPublishReference == "" {
+		conf.KeyPublishReference = defaultConfig.KeyPublishReference
+	}
+	if conf.KeyDuration == "" {
+		conf.KeyDuration = defaultConfig.KeyDuration
+	}
+	if conf.KeyCaller == "" {
+		conf.KeyCaller = defaultConfig.KeyCaller
+	}
+	if conf.KeyFunction == "" {
+		conf.KeyFunction = defaultConfig.KeyFunction
+	}
+	if conf.KeyLoggerLevel == "" {
+		conf.KeyLoggerLevel = defaultConfig.KeyLoggerLevel
+	}
+	if conf.KeyEnvironment == "" {
+		conf.KeyEnvironment = defaultConfig.KeyEnvironment
+	}
+	if conf.KeyRegion == "" {
+		conf.KeyRegion = defaultConfig.KeyRegion
+	}
+	if conf.KeyVersion == "" {
+		conf.KeyVersion = defaultConfig.KeyVersion
+	}
+	if conf.KeyGitCommit == "" {
+		conf.KeyGitCommit = defaultConfig.KeyGitCommit
+	}
+	if conf.KeyHost == "" {
+		conf.KeyHost = defaultConfig.KeyHost
+	}
+	if conf.KeyPodName == "" {
+		conf.KeyPodName = defaultConfig.KeyPodName
+	}
+	if conf.KeyCluster == "" {
+		conf.KeyCluster = defaultConfig.KeyCluster
+	}
+	if conf.KeyReason == "" {
+		conf.KeyReason = defaultConfig.KeyReason
+	}
+	if conf.KeyConfig == "" {
+		conf.KeyConfig = defaultConfig.KeyConfig
+	}
+	if conf.KeyDependency == "" {
+		conf.KeyDependency = defaultConfig.KeyDependency
+	}
+	if conf.KeyCheck == "" {
+		conf.KeyCheck = defaultConfig.KeyCheck
+	}
+	if conf.KeyHealthy == "" {
+		conf.KeyHealthy = defaultConfig.KeyHealthy
+	}
+	if conf.KeyOutput == "" {
+		conf.KeyOutput = defaultConfig.KeyOutput
+	}
 	return &conf
 }
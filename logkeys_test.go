@@ -21,6 +21,24 @@ func TestGetDefaultKeyNamesConfig(t *testing.T) {
 	assert.Equal(t, conf.KeyContentType, DefaultKeyContentType)
 	assert.Equal(t, conf.KeyEventCategory, DefaultKeyEventCategory)
 	assert.Equal(t, conf.KeyEventMsg, DefaultKeyEventMsg)
+	assert.Equal(t, conf.KeyPublishReference, DefaultKeyPublishReference)
+	assert.Equal(t, conf.KeyDuration, DefaultKeyDuration)
+	assert.Equal(t, conf.KeyCaller, DefaultKeyCaller)
+	assert.Equal(t, conf.KeyFunction, DefaultKeyFunction)
+	assert.Equal(t, conf.KeyLoggerLevel, DefaultKeyLoggerLevel)
+	assert.Equal(t, conf.KeyEnvironment, DefaultKeyEnvironment)
+	assert.Equal(t, conf.KeyRegion, DefaultKeyRegion)
+	assert.Equal(t, conf.KeyVersion, DefaultKeyVersion)
+	assert.Equal(t, conf.KeyGitCommit, DefaultKeyGitCommit)
+	assert.Equal(t, conf.KeyHost, DefaultKeyHost)
+	assert.Equal(t, conf.KeyPodName, DefaultKeyPodName)
+	assert.Equal(t, conf.KeyCluster, DefaultKeyCluster)
+	assert.Equal(t, conf.KeyReason, DefaultKeyReason)
+	assert.Equal(t, conf.KeyConfig, DefaultKeyConfig)
+	assert.Equal(t, conf.KeyDependency, DefaultKeyDependency)
+	assert.Equal(t, conf.KeyCheck, DefaultKeyCheck)
+	assert.Equal(t, conf.KeyHealthy, DefaultKeyHealthy)
+	assert.Equal(t, conf.KeyOutput, DefaultKeyOutput)
 }
 
 func TestGetFullKeyNameConfig(t *testing.T) {
@@ -45,4 +63,22 @@ func TestGetFullKeyNameConfig(t *testing.T) {
 	assert.Equal(t, conf.KeyContentType, DefaultKeyContentType)
 	assert.Equal(t, conf.KeyEventCategory, DefaultKeyEventCategory)
 	assert.Equal(t, conf.KeyEventMsg, DefaultKeyEventMsg)
+	assert.Equal(t, conf.KeyPublishReference, DefaultKeyPublishReference)
+	assert.Equal(t, conf.KeyDuration, DefaultKeyDuration)
+	assert.Equal(t, conf.KeyCaller, DefaultKeyCaller)
+	assert.Equal(t, conf.KeyFunction, DefaultKeyFunction)
+	assert.Equal(t, conf.KeyLoggerLevel, DefaultKeyLoggerLevel)
+	assert.Equal(t, conf.KeyEnvironment, DefaultKeyEnvironment)
+	assert.Equal(t, conf.KeyRegion, DefaultKeyRegion)
+	assert.Equal(t, conf.KeyVersion, DefaultKeyVersion)
+	assert.Equal(t, conf.KeyGitCommit, DefaultKeyGitCommit)
+	assert.Equal(t, conf.KeyHost, DefaultKeyHost)
+	assert.Equal(t, conf.KeyPodName, DefaultKeyPodName)
+	assert.Equal(t, conf.KeyCluster, DefaultKeyCluster)
+	assert.Equal(t, conf.KeyReason, DefaultKeyReason)
+	assert.Equal(t, conf.KeyConfig, DefaultKeyConfig)
+	assert.Equal(t, conf.KeyDependency, DefaultKeyDependency)
+	assert.Equal(t, conf.KeyCheck, DefaultKeyCheck)
+	assert.Equal(t, conf.KeyHealthy, DefaultKeyHealthy)
+	assert.Equal(t, conf.KeyOutput, DefaultKeyOutput)
 }
@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"os"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The environment variables read by DetectServiceMetadata.
+// POD_NAME and CLUSTER_NAME are expected to be set from the Kubernetes downward API
+// and the cluster configuration respectively, e.g.
+//
+//	env:
+//	  - name: POD_NAME
+//	    valueFrom:
+//	      fieldRef:
+//	        fieldPath: metadata.name
+const (
+	EnvEnvironment = "ENVIRONMENT"
+	EnvRegion      = "REGION"
+	EnvAWSRegion   = "AWS_REGION"
+	EnvVersion     = "APP_VERSION"
+	EnvGitCommit   = "GIT_COMMIT"
+	EnvHostname    = "HOSTNAME"
+	EnvPodName     = "POD_NAME"
+	EnvCluster     = "CLUSTER_NAME"
+)
+
+const (
+	develVersion       = "(devel)"
+	vcsRevisionSetting = "vcs.revision"
+)
+
+// ServiceMetadata describes the deployment of the service. Its non-empty fields are logged
+// with every entry alongside the service name, so that the entries can be searched by region, deploy, etc.
+type ServiceMetadata struct {
+	Environment string
+	Region      string
+	Version     string
+	GitCommit   string
+	Host        string
+	PodName     string
+	Cluster     string
+}
+
+// DetectServiceMetadata returns ServiceMetadata populated from the environment variables above.
+// The version and the git commit default to the module version and the VCS revision from the build info
+// and the host defaults to the host name reported by the kernel.
+func DetectServiceMetadata() ServiceMetadata {
+	return detectServiceMetadata(os.LookupEnv, debug.ReadBuildInfo, os.Hostname)
+}
+
+func detectServiceMetadata(
+	lookupEnv func(key string) (string, bool),
+	readBuildInfo func() (*debug.BuildInfo, bool),
+	hostname func() (string, error),
+) ServiceMetadata {
+	getenv := func(keys ...string) string {
+		for _, key := range keys {
+			if value, _ := lookupEnv(key); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	meta := ServiceMetadata{
+		Environment: getenv(EnvEnvironment),
+		Region:      getenv(EnvRegion, EnvAWSRegion),
+		Version:     getenv(EnvVersion),
+		GitCommit:   getenv(EnvGitCommit),
+		Host:        getenv(EnvHostname),
+		PodName:     getenv(EnvPodName),
+		Cluster:     getenv(EnvCluster),
+	}
+
+	if info, ok := readBuildInfo(); ok {
+		if meta.Version == "" && info.Main.Version != develVersion {
+			meta.Version = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			if meta.GitCommit == "" && s.Key == vcsRevisionSetting {
+				meta.GitCommit = s.Value
+			}
+		}
+	}
+	if meta.Host == "" {
+		// The host is just left out if its name can't be read
+		meta.Host, _ = hostname()
+	}
+	return meta
+}
+
+// fields returns the log entry fields of the non-empty metadata with the key names from keyConf.
+func (m ServiceMetadata) fields(keyConf *KeyNamesConfig) logrus.Fields {
+	fields := logrus.Fields{}
+	for k, v := range map[string]string{
+		keyConf.KeyEnvironment: m.Environment,
+		keyConf.KeyRegion:      m.Region,
+		keyConf.KeyVersion:     m.Version,
+		keyConf.KeyGitCommit:   m.GitCommit,
+		keyConf.KeyHost:        m.Host,
+		keyConf.KeyPodName:     m.PodName,
+		keyConf.KeyCluster:     m.Cluster,
+	} {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// SetServiceMetadata sets the service metadata logged with every entry, see DetectServiceMetadata.
+// The metadata fields are static fields: they replace the static fields with the same keys,
+// and the entry fields take precedence over them.
+// It has effect only on loggers with the UPP log format and should be called before the logger is used.
+func (ulog *UPPLogger) SetServiceMetadata(meta ServiceMetadata) {
+	if f, ok := ulog.uppFormatter(); ok {
+		f.staticFields = withServiceMetadata(f.staticFields, meta, ulog.keyConf)
+	}
+}
+
+// withServiceMetadata returns a copy of the static fields with the metadata fields added.
+func withServiceMetadata(staticFields logrus.Fields, meta ServiceMetadata, keyConf *KeyNamesConfig) logrus.Fields {
+	metaFields := meta.fields(keyConf)
+	fields := make(logrus.Fields, len(staticFields)+len(metaFields))
+	for k, v := range staticFields {
+		fields[k] = v
+	}
+	for k, v := range metaFields {
+		fields[k] = v
+	}
+	return fields
+}
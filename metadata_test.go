@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"runtime/debug"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBuildInfo() (*debug.BuildInfo, bool) {
+	return &debug.BuildInfo{
+		Main:     debug.Module{Path: "github.com/Financial-Times/test-service", Version: "v1.2.3"},
+		Settings: []debug.BuildSetting{{Key: "vcs", Value: "git"}, {Key: "vcs.revision", Value: "0123abcd"}},
+	}, true
+}
+
+func testHostname() (string, error) {
+	return "test-host", nil
+}
+
+func TestDetectServiceMetadata(t *testing.T) {
+	meta := detectServiceMetadata(lookupEnvMap(map[string]string{
+		EnvEnvironment: "prod",
+		EnvAWSRegion:   "eu-west-1",
+		EnvPodName:     "test-service-5d8f7c9b4-x2x7k",
+		EnvCluster:     "upp-prod-delivery-eu",
+	}), testBuildInfo, testHostname)
+
+	assert.Equal(t, ServiceMetadata{
+		Environment: "prod",
+		Region:      "eu-west-1",
+		Version:     "v1.2.3",
+		GitCommit:   "0123abcd",
+		Host:        "test-host",
+		PodName:     "test-service-5d8f7c9b4-x2x7k",
+		Cluster:     "upp-prod-delivery-eu",
+	}, meta)
+}
+
+func TestDetectServiceMetadataEnvPrecedence(t *testing.T) {
+	meta := detectServiceMetadata(lookupEnvMap(map[string]string{
+		EnvRegion:    "eu",
+		EnvAWSRegion: "eu-west-1",
+		EnvVersion:   "v2.0.0",
+		EnvGitCommit: "fedcba98",
+		EnvHostname:  "env-host",
+	}), testBuildInfo, testHostname)
+
+	assert.Equal(t, "eu", meta.Region)
+	assert.Equal(t, "v2.0.0", meta.Version)
+	assert.Equal(t, "fedcba98", meta.GitCommit)
+	assert.Equal(t, "env-host", meta.Host)
+}
+
+func TestDetectServiceMetadataWithoutBuildInfo(t *testing.T) {
+	develBuildInfo := func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}}, true
+	}
+	failingHostname := func() (string, error) {
+		return "", errors.New("no host name")
+	}
+
+	meta := detectServiceMetadata(lookupEnvMap(nil), develBuildInfo, failingHostname)
+	assert.Equal(t, ServiceMetadata{}, meta)
+}
+
+func TestSetServiceMetadata(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName, KeyNamesConfig{KeyRegion: "aws_region"})
+	ulog.Out = out
+	ulog.SetServiceMetadata(ServiceMetadata{Environment: "prod", Region: "eu-west-1", Version: "v1.2.3"})
+
+	ulog.Info(testMsg)
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, "prod", logged[DefaultKeyEnvironment])
+	assert.Equal(t, "eu-west-1", logged["aws_region"])
+	assert.Equal(t, "v1.2.3", logged[DefaultKeyVersion])
+	assert.NotContains(t, logged, DefaultKeyRegion)
+	assert.NotContains(t, logged, DefaultKeyGitCommit, "The empty metadata should not be logged")
+	assert.NotContains(t, logged, DefaultKeyHost)
+}
+
+func TestServiceMetadataEntryFieldsPrecedence(t *testing.T) {
+	meta := ServiceMetadata{Host: "pod-host", Version: "1.2.3", Region: "eu-west-1"}
+	tests := map[string][]Option{
+		"UPP formatter":    {WithServiceMetadata(meta)},
+		"custom formatter": {WithServiceMetadata(meta), WithFormatter(&logrus.JSONFormatter{})},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ulog, err := New(testServiceName, append(opts, WithOutput(out))...)
+			require.NoError(t, err)
+
+			ulog.WithField(DefaultKeyHost, "upstream.example.com").WithField(DefaultKeyVersion, "doc-v7").Info(testMsg)
+
+			var logged map[string]interface{}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+			assert.Equal(t, "upstream.example.com", logged[DefaultKeyHost], "The entry fields should take precedence over the metadata")
+			assert.Equal(t, "doc-v7", logged[DefaultKeyVersion])
+			assert.Equal(t, "eu-west-1", logged[DefaultKeyRegion])
+		})
+	}
+}
+
+func TestNewWithServiceMetadata(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithOutput(out), WithServiceMetadata(ServiceMetadata{Cluster: "upp-prod-publish-eu"}))
+	require.NoError(t, err)
+
+	ulog.Info(testMsg)
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, "upp-prod-publish-eu", logged[DefaultKeyCluster])
+}
+
+func TestNewFromEnvServiceMetadata(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := newFromEnv(lookupEnvMap(map[string]string{
+		EnvAppName:     testServiceName,
+		EnvEnvironment: "prod",
+		EnvPodName:     "test-pod",
+		EnvHostname:    "test-host",
+	}))
+	require.NoError(t, err)
+	ulog.Out = out
+
+	ulog.Info(testMsg)
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, "prod", logged[DefaultKeyEnvironment])
+	assert.Equal(t, "test-pod", logged[DefaultKeyPodName])
+	assert.Equal(t, "test-host", logged[DefaultKeyHost])
+}
+
+func TestServiceMetadataWithStaticFields(t *testing.T) {
+	meta := ServiceMetadata{Region: "eu-west-1", Cluster: "upp-prod-publish-eu"}
+	static := WithStaticFields(map[string]interface{}{"team": "content", DefaultKeyRegion: "us-east-1"})
+	tests := map[string][]Option{
+		"UPP formatter":    {static, WithServiceMetadata(meta)},
+		"custom formatter": {static, WithServiceMetadata(meta), WithFormatter(&logrus.JSONFormatter{})},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			ulog, err := New(testServiceName, append(opts, WithOutput(out))...)
+			require.NoError(t, err)
+
+			ulog.WithField(DefaultKeyCluster, "upp-staging").Info(testMsg)
+
+			var logged map[string]interface{}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+			assert.Equal(t, "content", logged["team"])
+			assert.Equal(t, "eu-west-1", logged[DefaultKeyRegion], "The metadata should replace the static field with the same key")
+			assert.Equal(t, "upp-staging", logged[DefaultKeyCluster], "The entry fields should take precedence over the metadata")
+		})
+	}
+}
+
+func TestSetServiceMetadataKeepsStaticFields(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName, WithOutput(out), WithStaticFields(map[string]interface{}{"team": "content"}))
+	require.NoError(t, err)
+	ulog.SetServiceMetadata(ServiceMetadata{Environment: "prod"})
+
+	ulog.Info(testMsg)
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, "content", logged["team"])
+	assert.Equal(t, "prod", logged[DefaultKeyEnvironment])
+}
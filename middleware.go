@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"crypto/rand"
+	"net/http"
+	"time"
+)
+
+const (
+	// TransactionIDHeader is the HTTP header carrying the transaction ID of the request.
+	TransactionIDHeader = "X-Request-Id"
+
+	transactionIDPrefix   = "tid_"
+	transactionIDLength   = 10
+	transactionIDAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	accessLogMsg = "Access log"
+)
+
+// HTTPMiddleware wraps the handler so that every request has a transaction ID and is logged once served.
+// The transaction ID is taken from the X-Request-Id header or generated if missing.
+// It is stored in the request context (see ContextWithTransactionID) and set on the response X-Request-Id header.
+// The access log entry contains the method, path, status, bytes written and duration in milliseconds of the request.
+func (ulog *UPPLogger) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		tid := r.Header.Get(TransactionIDHeader)
+		if tid == "" {
+			tid = NewTransactionID()
+		}
+		ctx := ContextWithTransactionID(r.Context(), tid)
+		w.Header().Set(TransactionIDHeader, tid)
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		ulog.WithContext(ctx).WithFields(map[string]interface{}{
+			"method":   r.Method,
+			"path":     r.URL.Path,
+			"status":   rec.status,
+			"bytes":    rec.bytes,
+			"duration": time.Since(start).Nanoseconds() / int64(time.Millisecond),
+		}).Info(accessLogMsg)
+	})
+}
+
+// NewTransactionID generates a new random transaction ID in the UPP format, e.g. tid_4bq0rn7yzp.
+func NewTransactionID() string {
+	b := make([]byte, transactionIDLength)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand never fails on the supported platforms
+		panic(err)
+	}
+	for i := range b {
+		b[i] = transactionIDAlphabet[int(b[i])%len(transactionIDAlphabet)]
+	}
+	return transactionIDPrefix + string(b)
+}
+
+// responseRecorder records the status code and the number of bytes written by the wrapped handler.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += n
+	return n, err
+}
+
+// Flush implements http.Flusher if the wrapped http.ResponseWriter supports it.
+func (rec *responseRecorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMiddlewareWithTransactionID(t *testing.T) {
+	conf := KeyNamesConfig{KeyTransactionID: "test-transaction-id-key"}
+	ulog := NewUPPInfoLogger("test_service", conf)
+	hook := test.NewLocal(ulog.Logger)
+
+	var ctxTID string
+	h := ulog.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxTID, _ = TransactionIDFromContext(r.Context())
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("hello"))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/content/123?foo=bar", nil)
+	req.Header.Set(TransactionIDHeader, "tid_test")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, "tid_test", ctxTID)
+	assert.Equal(t, "tid_test", rec.Header().Get(TransactionIDHeader))
+
+	require.Len(t, hook.Entries, 1)
+	e := hook.LastEntry()
+	assert.Equal(t, logrus.InfoLevel, e.Level)
+	assert.Equal(t, accessLogMsg, e.Message)
+	assert.Len(t, e.Data, 6)
+	assert.Equal(t, "tid_test", e.Data[conf.KeyTransactionID])
+	assert.Equal(t, http.MethodPost, e.Data["method"])
+	assert.Equal(t, "/content/123", e.Data["path"])
+	assert.Equal(t, http.StatusAccepted, e.Data["status"])
+	assert.Equal(t, 5, e.Data["bytes"])
+	assert.Contains(t, e.Data, "duration")
+}
+
+func TestHTTPMiddlewareGeneratesTransactionID(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+
+	var ctxTID string
+	h := ulog.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxTID, _ = TransactionIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/__health", nil))
+
+	assert.Regexp(t, "^tid_[a-z0-9]{10}$", ctxTID)
+	assert.Equal(t, ctxTID, rec.Header().Get(TransactionIDHeader))
+
+	require.Len(t, hook.Entries, 1)
+	assert.Equal(t, ctxTID, hook.LastEntry().Data[DefaultKeyTransactionID])
+	assert.Equal(t, http.StatusOK, hook.LastEntry().Data["status"])
+	assert.Equal(t, 0, hook.LastEntry().Data["bytes"])
+}
+
+func TestNewTransactionID(t *testing.T) {
+	tidRegexp := regexp.MustCompile("^tid_[a-z0-9]{10}$")
+	tid1 := NewTransactionID()
+	tid2 := NewTransactionID()
+
+	assert.Regexp(t, tidRegexp, tid1)
+	assert.Regexp(t, tidRegexp, tid2)
+	assert.NotEqual(t, tid1, tid2)
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const invalidMonitoringEventMsg = "Invalid monitoring event"
+
+// MonitoringEventFields are the fields of a monitoring event.
+// EventName, TransactionID and ContentType are required, the rest are optional and logged only when set.
+type MonitoringEventFields struct {
+	EventName        string
+	TransactionID    string
+	ContentType      string
+	UUID             string
+	IsValid          *bool
+	PublishReference string
+	Duration         time.Duration
+}
+
+// Validate returns an error listing the missing required fields, if any.
+func (e MonitoringEventFields) Validate() error {
+	var missing []string
+	if e.EventName == "" {
+		missing = append(missing, "event name")
+	}
+	if e.TransactionID == "" {
+		missing = append(missing, "transaction ID")
+	}
+	if e.ContentType == "" {
+		missing = append(missing, "content type")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("invalid monitoring event %q: missing %s", e.EventName, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// fields returns the log entry fields of the monitoring event with the key names from keyConf.
+func (e MonitoringEventFields) fields(keyConf *KeyNamesConfig) map[string]interface{} {
+	fields := map[string]interface{}{
+		keyConf.KeyMonitoringEvent: "true",
+		keyConf.KeyEventName:       e.EventName,
+		keyConf.KeyTransactionID:   e.TransactionID,
+		keyConf.KeyContentType:     e.ContentType,
+	}
+	if e.UUID != "" {
+		fields[keyConf.KeyUUID] = e.UUID
+	}
+	if e.IsValid != nil {
+		fields[keyConf.KeyIsValid] = strconv.FormatBool(*e.IsValid)
+	}
+	if e.PublishReference != "" {
+		fields[keyConf.KeyPublishReference] = e.PublishReference
+	}
+	if e.Duration != 0 {
+		fields[keyConf.KeyDuration] = durationMillis(e.Duration)
+	}
+	return fields
+}
+
+// Bool returns a pointer to the value, e.g. for setting MonitoringEventFields.IsValid.
+func Bool(value bool) *bool {
+	return &value
+}
+
+// durationMillis returns the duration in milliseconds, the unit of all the durations in the UPP logs.
+func durationMillis(d time.Duration) int64 {
+	return d.Nanoseconds() / int64(time.Millisecond)
+}
+
+// SetMonitoringEventValidationHandler sets the function called with the validation error of every invalid
+// monitoring event logged with WithMonitoringEventFields. By default the violations are logged as warnings.
+// Tests can use it to fail on invalid monitoring events:
+//
+//	ulog.SetMonitoringEventValidationHandler(func(err error) { t.Error(err) })
+//
+// It should be called before the logger is used.
+func (ulog *UPPLogger) SetMonitoringEventValidationHandler(handler func(err error)) {
+	ulog.onInvalidEvent = handler
+}
+
+func (ulog *UPPLogger) validateMonitoringEvent(e MonitoringEventFields) {
+	err := e.Validate()
+	if err == nil {
+		return
+	}
+	if ulog.onInvalidEvent != nil {
+		ulog.onInvalidEvent(err)
+		return
+	}
+	ulog.WithTransactionID(e.TransactionID).WithError(err).Warn(invalidMonitoringEventMsg)
+}
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitoringEventFieldsValidate(t *testing.T) {
+	valid := MonitoringEventFields{EventName: testEvent, TransactionID: testTID, ContentType: testContentType}
+	assert.NoError(t, valid.Validate())
+
+	err := MonitoringEventFields{EventName: testEvent}.Validate()
+	assert.EqualError(t, err, `invalid monitoring event "apocalypse": missing transaction ID, content type`)
+
+	err = MonitoringEventFields{}.Validate()
+	assert.EqualError(t, err, `invalid monitoring event "": missing event name, transaction ID, content type`)
+}
+
+func TestWithMonitoringEventFields(t *testing.T) {
+	conf := KeyNamesConfig{KeyDuration: "test-duration-key"}
+	ulog := NewUPPInfoLogger("test_service", conf)
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.WithMonitoringEventFields(MonitoringEventFields{
+		EventName:        testEvent,
+		TransactionID:    testTID,
+		ContentType:      testContentType,
+		UUID:             "test-uuid",
+		IsValid:          Bool(false),
+		PublishReference: "test-publish-ref",
+		Duration:         1500 * time.Millisecond,
+	}).Info("a info message")
+
+	require.Len(t, hook.Entries, 1)
+	assert.Equal(t, map[string]interface{}{
+		DefaultKeyMonitoringEvent:  "true",
+		DefaultKeyEventName:        testEvent,
+		DefaultKeyTransactionID:    testTID,
+		DefaultKeyContentType:      testContentType,
+		DefaultKeyUUID:             "test-uuid",
+		DefaultKeyIsValid:          "false",
+		DefaultKeyPublishReference: "test-publish-ref",
+		conf.KeyDuration:           int64(1500),
+	}, map[string]interface{}(hook.LastEntry().Data))
+}
+
+func TestLogEntryWithMonitoringEventFields(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.WithField("foo", "bar").
+		WithMonitoringEventFields(MonitoringEventFields{EventName: testEvent, TransactionID: testTID, ContentType: testContentType}).
+		Info("a info message")
+
+	require.Len(t, hook.Entries, 1)
+	assert.Len(t, hook.LastEntry().Data, 5)
+	assert.Equal(t, "bar", hook.LastEntry().Data["foo"])
+	assert.Equal(t, "true", hook.LastEntry().Data[DefaultKeyMonitoringEvent])
+}
+
+func TestInvalidMonitoringEventLogsWarning(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.WithMonitoringEventFields(MonitoringEventFields{EventName: testEvent, TransactionID: testTID}).Info("a info message")
+
+	require.Len(t, hook.Entries, 2)
+	warning := hook.Entries[0]
+	assert.Equal(t, logrus.WarnLevel, warning.Level)
+	assert.Equal(t, invalidMonitoringEventMsg, warning.Message)
+	assert.Equal(t, testTID, warning.Data[DefaultKeyTransactionID])
+	assert.EqualError(t, warning.Data[DefaultKeyError].(error), `invalid monitoring event "apocalypse": missing content type`)
+	assert.Equal(t, "a info message", hook.LastEntry().Message)
+}
+
+func TestInvalidMonitoringEventHandler(t *testing.T) {
+	ulog := NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	var errs []error
+	ulog.SetMonitoringEventValidationHandler(func(err error) { errs = append(errs, err) })
+
+	ulog.WithMonitoringEventFields(MonitoringEventFields{TransactionID: testTID, ContentType: testContentType}).Info("a info message")
+
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `invalid monitoring event "": missing event name`)
+	assert.Len(t, hook.Entries, 1)
+}
@@ -0,0 +1,7 @@
+//go:build !race
+// +build !race
+
+package logger
+
+// raceEnabled reports whether the tests run with the race detector, which makes sync.Pool drop pooled values randomly.
+const raceEnabled = false
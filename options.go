@@ -0,0 +1,245 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Option configures UPPLogger created with New.
+type Option func(c *config) error
+
+type config struct {
+	level        logrus.Level
+	keyConf      KeyNamesConfig
+	out          io.Writer
+	hooks        []logrus.Hook
+	staticFields logrus.Fields
+	now          func() time.Time
+	format       string
+	formatter    logrus.Formatter
+	metadata     *ServiceMetadata
+	fieldOrder   []string
+	reportCaller bool
+	lookupEnv    func(key string) (string, bool)
+}
+
+// New initializes UPP logger with structured logging format and the given options.
+// Unlike NewUPPLogger, it returns an error for an empty service name or an invalid option
+// instead of falling back to the defaults or failing on the first log entry.
+// The defaults are log level INFO, the default key names and output to os.Stderr.
+func New(serviceName string, opts ...Option) (*UPPLogger, error) {
+	if serviceName == "" {
+		return nil, errors.New("service name is required")
+	}
+	c := &config{level: logrus.InfoLevel, lookupEnv: os.LookupEnv}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	keyConf := GetFullKeyNameConfig(c.keyConf)
+	if c.metadata != nil {
+		c.staticFields = withServiceMetadata(c.staticFields, *c.metadata, keyConf)
+	}
+	for k := range c.staticFields {
+		switch k {
+		case keyConf.KeyLogLevel, keyConf.KeyMsg, keyConf.KeyTime, keyConf.KeyServiceName:
+			return nil, fmt.Errorf("static field %q conflicts with a field logged by the UPP logger", k)
+		}
+	}
+
+	ulog := newUPPLogger(serviceName, keyConf)
+	switch c.format {
+	case FormatConsole:
+		noColour, _ := c.lookupEnv(EnvNoColour)
+		ulog.Formatter = newConsoleFormatter(serviceName, keyConf, noColour == "")
+	case FormatLogfmt:
+		ulog.Formatter = newLogfmtFormatter(serviceName, keyConf)
+	}
+	ulog.SetLevel(c.level)
+	ulog.SetReportCaller(c.reportCaller)
+	if c.out != nil {
+		ulog.Out = c.out
+	}
+	for _, hook := range c.hooks {
+		ulog.AddHook(hook)
+	}
+	if c.formatter != nil {
+		ulog.Formatter = c.formatter
+		if len(c.staticFields) > 0 || c.now != nil {
+			ulog.Formatter = &staticFormatter{Formatter: c.formatter, fields: c.staticFields, now: c.now}
+		}
+	} else {
+		f, _ := ulog.uppFormatter()
+		f.staticFields = c.staticFields
+		f.now = c.now
+		if c.fieldOrder != nil {
+			ulog.SetFieldOrder(c.fieldOrder...)
+		}
+	}
+	return ulog, nil
+}
+
+// WithLevel sets the log level, e.g. "debug" or "info".
+func WithLevel(level string) Option {
+	return func(c *config) error {
+		parsed, err := logrus.ParseLevel(level)
+		if err != nil {
+			return fmt.Errorf("invalid log level: %v", err)
+		}
+		c.level = parsed
+		return nil
+	}
+}
+
+// WithKeyNames sets the names of the field keys logged by the UPP logger methods.
+// The key names missing from kconf are the default ones.
+func WithKeyNames(kconf KeyNamesConfig) Option {
+	return func(c *config) error {
+		c.keyConf = kconf
+		return nil
+	}
+}
+
+// WithOutput sets the writer the log entries are written to.
+func WithOutput(out io.Writer) Option {
+	return func(c *config) error {
+		if out == nil {
+			return errors.New("log output must not be nil")
+		}
+		c.out = out
+		return nil
+	}
+}
+
+// WithHooks adds the hooks to the logger.
+func WithHooks(hooks ...logrus.Hook) Option {
+	return func(c *config) error {
+		for _, hook := range hooks {
+			if hook == nil {
+				return errors.New("log hook must not be nil")
+			}
+		}
+		c.hooks = append(c.hooks, hooks...)
+		return nil
+	}
+}
+
+// WithStaticFields sets the fields added to every written log entry, e.g. the team owning the service.
+// The fields of the entries take precedence over the static ones with the same key.
+// The static fields are added by the formatter, so they are not visible to the logger hooks.
+func WithStaticFields(fields map[string]interface{}) Option {
+	return func(c *config) error {
+		if c.staticFields == nil {
+			c.staticFields = make(logrus.Fields, len(fields))
+		}
+		for k, v := range fields {
+			if k == "" {
+				return errors.New("static field key must not be empty")
+			}
+			c.staticFields[k] = v
+		}
+		return nil
+	}
+}
+
+// WithServiceMetadata sets the service metadata logged with every entry, e.g. WithServiceMetadata(DetectServiceMetadata()).
+// The metadata fields are logged as static fields, see UPPLogger.SetServiceMetadata.
+func WithServiceMetadata(meta ServiceMetadata) Option {
+	return func(c *config) error {
+		c.metadata = &meta
+		return nil
+	}
+}
+
+// WithClock sets the function returning the time of the written log entries instead of the time they were logged.
+// It is meant for tests which need deterministic log output.
+func WithClock(now func() time.Time) Option {
+	return func(c *config) error {
+		if now == nil {
+			return errors.New("clock must not be nil")
+		}
+		c.now = now
+		return nil
+	}
+}
+
+// WithFormat sets the UPP log format, FormatJSON (default), FormatLogfmt or FormatConsole.
+// The console format is colourised, unless the NO_COLOR environment variable is set to a non-empty value.
+func WithFormat(format string) Option {
+	return func(c *config) error {
+		switch format {
+		case FormatJSON, FormatLogfmt, FormatConsole:
+			c.format = format
+			return nil
+		}
+		return fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// WithFieldOrder sets the keys of the fields written first, see UPPLogger.SetFieldOrder.
+func WithFieldOrder(keys ...string) Option {
+	return func(c *config) error {
+		c.fieldOrder = append([]string{}, keys...)
+		return nil
+	}
+}
+
+// WithReportCaller makes the logger log the caller (file:line) and the function that logged each entry,
+// see UPPLogger.SetReportCaller.
+func WithReportCaller() Option {
+	return func(c *config) error {
+		c.reportCaller = true
+		return nil
+	}
+}
+
+// withLookupEnv sets the function reading the environment variables, os.LookupEnv by default.
+func withLookupEnv(lookupEnv func(key string) (string, bool)) Option {
+	return func(c *config) error {
+		c.lookupEnv = lookupEnv
+		return nil
+	}
+}
+
+// WithFormatter replaces the UPP log formatter, e.g. with one of the logrus formatters.
+// SetRedactor has no effect on loggers with a custom formatter.
+func WithFormatter(formatter logrus.Formatter) Option {
+	return func(c *config) error {
+		if formatter == nil {
+			return errors.New("log formatter must not be nil")
+		}
+		c.formatter = formatter
+		return nil
+	}
+}
+
+// staticFormatter adds the static fields and sets the clock time of the entries formatted by a custom formatter.
+type staticFormatter struct {
+	logrus.Formatter
+	fields logrus.Fields
+	now    func() time.Time
+}
+
+func (f *staticFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	e := *entry
+	if len(f.fields) > 0 {
+		e.Data = make(logrus.Fields, len(f.fields)+len(entry.Data))
+		for k, v := range f.fields {
+			e.Data[k] = v
+		}
+		for k, v := range entry.Data {
+			e.Data[k] = v
+		}
+	}
+	if f.now != nil {
+		e.Time = f.now()
+	}
+	return f.Formatter.Format(&e)
+}
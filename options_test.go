@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testClockTime = time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+func testClock() time.Time {
+	return testClockTime
+}
+
+func TestNewDefaults(t *testing.T) {
+	ulog, err := New(testServiceName)
+	require.NoError(t, err)
+
+	assert.Equal(t, logrus.InfoLevel, ulog.GetLevel())
+	assert.Equal(t, *GetDefaultKeyNamesConfig(), ulog.GetKeyNamesConfig())
+	assert.IsType(t, &ftJSONFormatter{}, ulog.Formatter)
+}
+
+func TestNewWithOptions(t *testing.T) {
+	out := new(bytes.Buffer)
+	hook := new(test.Hook)
+	ulog, err := New(testServiceName,
+		WithLevel("debug"),
+		WithKeyNames(KeyNamesConfig{KeyTransactionID: "request_id"}),
+		WithOutput(out),
+		WithHooks(hook),
+		WithStaticFields(map[string]interface{}{"environment": "test", "region": "eu"}),
+		WithStaticFields(map[string]interface{}{"version": "1.2.3"}),
+		WithClock(testClock),
+	)
+	require.NoError(t, err)
+
+	ulog.WithTransactionID(testTID).WithField("region", "us").Debug(testMsg)
+
+	require.Len(t, hook.Entries, 1)
+	assert.NotContains(t, hook.LastEntry().Data, "environment", "The static fields should not be visible to the hooks")
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, map[string]interface{}{
+		"request_id":          testTID,
+		"environment":         "test",
+		"region":              "us",
+		"version":             "1.2.3",
+		DefaultKeyMsg:         testMsg,
+		DefaultKeyLogLevel:    "debug",
+		DefaultKeyServiceName: testServiceName,
+		DefaultKeyTime:        "2020-03-04T05:06:07Z",
+	}, logged)
+}
+
+func TestNewWithFormatter(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog, err := New(testServiceName,
+		WithOutput(out),
+		WithFormatter(&logrus.JSONFormatter{}),
+		WithStaticFields(map[string]interface{}{"environment": "test"}),
+		WithClock(testClock),
+	)
+	require.NoError(t, err)
+
+	ulog.WithField("foo", "bar").Info(testMsg)
+
+	var logged map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logged))
+	assert.Equal(t, "test", logged["environment"])
+	assert.Equal(t, "bar", logged["foo"])
+	assert.Equal(t, "2020-03-04T05:06:07Z", logged["time"])
+	assert.NotContains(t, logged, DefaultKeyServiceName)
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := map[string]struct {
+		serviceName string
+		opts        []Option
+		expectedErr string
+	}{
+		"empty service name": {
+			opts:        []Option{WithLevel("info")},
+			expectedErr: "service name is required",
+		},
+		"invalid level": {
+			serviceName: testServiceName,
+			opts:        []Option{WithLevel("verbose")},
+			expectedErr: "invalid log level",
+		},
+		"nil output": {
+			serviceName: testServiceName,
+			opts:        []Option{WithOutput(nil)},
+			expectedErr: "log output must not be nil",
+		},
+		"nil hook": {
+			serviceName: testServiceName,
+			opts:        []Option{WithHooks(new(test.Hook), nil)},
+			expectedErr: "log hook must not be nil",
+		},
+		"empty static field key": {
+			serviceName: testServiceName,
+			opts:        []Option{WithStaticFields(map[string]interface{}{"": "value"})},
+			expectedErr: "static field key must not be empty",
+		},
+		"static field conflict": {
+			serviceName: testServiceName,
+			opts: []Option{
+				WithKeyNames(KeyNamesConfig{KeyMsg: "message"}),
+				WithStaticFields(map[string]interface{}{"message": "value"}),
+			},
+			expectedErr: `static field "message" conflicts`,
+		},
+		"nil clock": {
+			serviceName: testServiceName,
+			opts:        []Option{WithClock(nil)},
+			expectedErr: "clock must not be nil",
+		},
+		"nil formatter": {
+			serviceName: testServiceName,
+			opts:        []Option{WithFormatter(nil)},
+			expectedErr: "log formatter must not be nil",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ulog, err := New(test.serviceName, test.opts...)
+			assert.Nil(t, ulog)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
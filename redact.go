@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// RedactionMode defines how the sensitive values are redacted.
+type RedactionMode int
+
+const (
+	// RedactReplace replaces the sensitive values with [REDACTED].
+	RedactReplace RedactionMode = iota
+	// RedactHash replaces the sensitive values with a hash of them, so the same values can still be correlated.
+	RedactHash
+)
+
+const (
+	redactedValue    = "[REDACTED]"
+	redactHashPrefix = "sha256:"
+	redactHashLength = 16
+)
+
+// RedactionConfig configures which values Redactor redacts.
+type RedactionConfig struct {
+	// Keys are the field keys whose values are redacted. The keys are matched case-insensitively.
+	Keys []string
+	// KeyPatterns are regular expressions matching the field keys whose values are redacted.
+	KeyPatterns []string
+	// ValuePatterns are regular expressions matching the parts of the string values which are redacted.
+	ValuePatterns []string
+	// Mode defines how the values are redacted.
+	Mode RedactionMode
+}
+
+// DefaultRedactionConfig returns redaction config for the most common secrets and personal data:
+// credentials and API keys in fields and headers, bearer tokens and email addresses.
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{
+		Keys: []string{"authorization", "proxy-authorization", "cookie", "set-cookie", "x-api-key"},
+		KeyPatterns: []string{
+			`(?i)api[-_]?key`,
+			`(?i)password|passwd|secret`,
+			`(?i)(^|[-_])token$`,
+		},
+		ValuePatterns: []string{
+			`(?i)bearer\s+[a-z0-9\-._~+/]+=*`,
+			`(?i)(api[-_]?key|apikey)=[^&\s"]+`,
+			`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`,
+		},
+		Mode: RedactReplace,
+	}
+}
+
+// Redactor redacts sensitive values from the log entries, including the values nested in maps, slices and structs.
+type Redactor struct {
+	keys          map[string]bool
+	keyPatterns   []*regexp.Regexp
+	valuePatterns []*regexp.Regexp
+	mode          RedactionMode
+}
+
+// NewRedactor returns Redactor for the given config or an error if any of the patterns is not a valid regular expression.
+func NewRedactor(conf RedactionConfig) (*Redactor, error) {
+	r := &Redactor{keys: make(map[string]bool, len(conf.Keys)), mode: conf.Mode}
+	for _, k := range conf.Keys {
+		r.keys[strings.ToLower(k)] = true
+	}
+
+	var err error
+	if r.keyPatterns, err = compilePatterns(conf.KeyPatterns); err != nil {
+		return nil, fmt.Errorf("invalid redaction key pattern: %v", err)
+	}
+	if r.valuePatterns, err = compilePatterns(conf.ValuePatterns); err != nil {
+		return nil, fmt.Errorf("invalid redaction value pattern: %v", err)
+	}
+	return r, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// redactFields redacts the field values in place.
+func (r *Redactor) redactFields(fields map[string]interface{}) {
+	for k, v := range fields {
+		fields[k] = r.redact(k, v)
+	}
+}
+
+// redact returns the redacted value of the field with the given key.
+func (r *Redactor) redact(key string, value interface{}) interface{} {
+	if r.isSensitiveKey(key) {
+		return r.redactedValue(value)
+	}
+
+	switch v := value.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return v
+	case string:
+		return r.redactString(v)
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = r.redactString(s)
+		}
+		return redacted
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, nested := range v {
+			redacted[k] = r.redact(k, nested)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, nested := range v {
+			redacted[i] = r.redact("", nested)
+		}
+		return redacted
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		// The nested values are redacted in their JSON representation, as this is what ends up in the logs.
+		generic, err := toGenericJSON(value)
+		if err != nil {
+			return value
+		}
+		return r.redact("", generic)
+	case reflect.String:
+		return r.redactString(fmt.Sprint(value))
+	}
+	return value
+}
+
+func (r *Redactor) isSensitiveKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	if r.keys[strings.ToLower(key)] {
+		return true
+	}
+	for _, re := range r.keyPatterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) redactString(s string) string {
+	for _, re := range r.valuePatterns {
+		s = re.ReplaceAllStringFunc(s, r.redactedString)
+	}
+	return s
+}
+
+func (r *Redactor) redactedValue(value interface{}) interface{} {
+	if r.mode != RedactHash {
+		return redactedValue
+	}
+	if s, ok := value.(string); ok {
+		return r.redactedString(s)
+	}
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return redactedValue
+	}
+	return r.redactedString(string(serialized))
+}
+
+func (r *Redactor) redactedString(s string) string {
+	if r.mode != RedactHash {
+		return redactedValue
+	}
+	sum := sha256.Sum256([]byte(s))
+	return redactHashPrefix + hex.EncodeToString(sum[:])[:redactHashLength]
+}
+
+func toGenericJSON(value interface{}) (interface{}, error) {
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	dec := json.NewDecoder(bytes.NewReader(serialized))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCredentials struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Contact  string `json:"contact"`
+}
+
+func newTestRedactor(t *testing.T, mode RedactionMode) *Redactor {
+	conf := DefaultRedactionConfig()
+	conf.Mode = mode
+	r, err := NewRedactor(conf)
+	require.NoError(t, err)
+	return r
+}
+
+func TestNewRedactorInvalidPattern(t *testing.T) {
+	_, err := NewRedactor(RedactionConfig{KeyPatterns: []string{"("}})
+	assert.Error(t, err)
+
+	_, err = NewRedactor(RedactionConfig{ValuePatterns: []string{"[a-"}})
+	assert.Error(t, err)
+}
+
+func TestRedactorKeys(t *testing.T) {
+	r := newTestRedactor(t, RedactReplace)
+	fields := map[string]interface{}{
+		"Authorization": "Basic dXNlcjpwYXNz",
+		"api_key":       "abc123",
+		"access_token":  12345,
+		"content_type":  "application/json",
+	}
+
+	r.redactFields(fields)
+
+	assert.Equal(t, redactedValue, fields["Authorization"])
+	assert.Equal(t, redactedValue, fields["api_key"])
+	assert.Equal(t, redactedValue, fields["access_token"])
+	assert.Equal(t, "application/json", fields["content_type"])
+}
+
+func TestRedactorValues(t *testing.T) {
+	r := newTestRedactor(t, RedactReplace)
+
+	assert.Equal(t, "sent with [REDACTED]", r.redactString("sent with Bearer eyJhbGciOiJIUzI1NiJ9.e30.abc-_="))
+	assert.Equal(t, "/content?[REDACTED]&id=1", r.redactString("/content?apiKey=s3cr3t&id=1"))
+	assert.Equal(t, "notify [REDACTED] now", r.redactString("notify john.doe@ft.com now"))
+	assert.Equal(t, "nothing to hide", r.redactString("nothing to hide"))
+}
+
+func TestRedactorNestedValues(t *testing.T) {
+	r := newTestRedactor(t, RedactReplace)
+	header := http.Header{}
+	header.Set("Authorization", "Bearer abc")
+	header.Set("X-Api-Key", "abc")
+	header.Set("Accept", "application/json")
+
+	redacted := r.redact("headers", header)
+	assert.Equal(t, map[string]interface{}{
+		"Authorization": redactedValue,
+		"X-Api-Key":     redactedValue,
+		"Accept":        []interface{}{"application/json"},
+	}, redacted)
+
+	redacted = r.redact("credentials", &testCredentials{User: "john", Password: "pass", Contact: "john@ft.com"})
+	assert.Equal(t, map[string]interface{}{
+		"user":     "john",
+		"password": redactedValue,
+		"contact":  redactedValue,
+	}, redacted)
+
+	redacted = r.redact("recipients", []string{"john@ft.com", "team"})
+	assert.Equal(t, []string{redactedValue, "team"}, redacted)
+}
+
+func TestRedactorHashMode(t *testing.T) {
+	r := newTestRedactor(t, RedactHash)
+
+	first := r.redact("password", "pass")
+	second := r.redact("password", "pass")
+	other := r.redact("password", "other")
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+	assert.True(t, strings.HasPrefix(first.(string), redactHashPrefix))
+	assert.Len(t, first, len(redactHashPrefix)+redactHashLength)
+	assert.NotContains(t, r.redactString("mail john@ft.com"), "john@ft.com")
+}
+
+func TestUPPLoggerWithRedactor(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = out
+	ulog.SetRedactor(newTestRedactor(t, RedactReplace))
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer abc")
+	ulog.WithTransactionID(testTID).WithField("headers", header).Info("Request from john@ft.com")
+
+	var logLine map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &logLine))
+	assert.Equal(t, testTID, logLine[DefaultKeyTransactionID])
+	assert.Equal(t, "Request from [REDACTED]", logLine[DefaultKeyMsg])
+	assert.Equal(t, map[string]interface{}{"Authorization": redactedValue}, logLine["headers"])
+	assert.NotContains(t, out.String(), "abc")
+}
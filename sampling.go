@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"hash/fnv"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultSamplingInterval = time.Second
+	defaultSamplingFirst    = 100
+	samplingCounters        = 4096
+)
+
+// SamplingConfig configures Sampler.
+type SamplingConfig struct {
+	// Interval is the period for which the entries are counted. Defaults to 1 second.
+	Interval time.Duration
+	// First is the number of entries with the same level and message logged in each interval. Defaults to 100.
+	First uint64
+	// Thereafter defines that every Thereafter-th entry is logged after the first ones in the interval.
+	// If zero, all the entries after the first ones are dropped.
+	Thereafter uint64
+}
+
+// Sampler limits the number of the log entries with the same level and message logged per interval.
+// The entries with level error or higher and the monitoring events are never sampled,
+// as the monitoring relies on them.
+//
+// The entries are counted in a fixed number of counters indexed by a hash of the level and the message,
+// so the memory used by Sampler does not grow with the number of distinct messages.
+// As a consequence, distinct messages whose hashes collide share a counter, so a frequent message
+// may occasionally cause an unrelated one with the same level to be sampled out as well.
+type Sampler struct {
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	counters   [samplingCounters]samplingCounter
+	dropped    uint64
+	now        func() time.Time
+}
+
+type samplingCounter struct {
+	resetAt int64
+	count   uint64
+}
+
+// NewSampler returns Sampler with the given config.
+func NewSampler(conf SamplingConfig) *Sampler {
+	if conf.Interval <= 0 {
+		conf.Interval = defaultSamplingInterval
+	}
+	if conf.First == 0 {
+		// Otherwise all the sampled entries would be dropped
+		conf.First = defaultSamplingFirst
+	}
+	return &Sampler{
+		interval:   conf.Interval,
+		first:      conf.First,
+		thereafter: conf.Thereafter,
+		now:        time.Now,
+	}
+}
+
+// Dropped returns the total number of entries dropped by the sampler.
+func (s *Sampler) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// sample reports whether the entry with the level, the message and the fields should be logged.
+func (s *Sampler) sample(level logrus.Level, msg string, data logrus.Fields, keyConf *KeyNamesConfig) bool {
+	if level <= logrus.ErrorLevel || data[keyConf.KeyMonitoringEvent] == "true" {
+		return true
+	}
+
+	c := &s.counters[samplingIndex(level, msg)]
+	n := c.inc(s.now().UnixNano(), int64(s.interval))
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+	atomic.AddUint64(&s.dropped, 1)
+	return false
+}
+
+// inc increments the counter and returns its value, resetting it first if the interval has passed.
+func (c *samplingCounter) inc(now int64, interval int64) uint64 {
+	resetAt := atomic.LoadInt64(&c.resetAt)
+	if resetAt > now {
+		return atomic.AddUint64(&c.count, 1)
+	}
+	if atomic.CompareAndSwapInt64(&c.resetAt, resetAt, now+interval) {
+		atomic.StoreUint64(&c.count, 1)
+		return 1
+	}
+	return atomic.AddUint64(&c.count, 1)
+}
+
+func samplingIndex(level logrus.Level, msg string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte{byte(level)})
+	h.Write([]byte(msg))
+	return h.Sum32() % samplingCounters
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSampledLogger(conf SamplingConfig) (*UPPLogger, *Sampler, *bytes.Buffer) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPLogger(testServiceName, "debug")
+	ulog.Out = out
+	sampler := NewSampler(conf)
+	ulog.SetSampler(sampler)
+	return ulog, sampler, out
+}
+
+func TestSamplerKeepsFirstAndThereafter(t *testing.T) {
+	ulog, sampler, out := newTestSampledLogger(SamplingConfig{Interval: time.Hour, First: 2, Thereafter: 3})
+
+	for i := 0; i < 10; i++ {
+		ulog.Warn("consumer lagging")
+	}
+
+	// entries 1, 2, 5 and 8 are logged
+	assert.Equal(t, 4, strings.Count(out.String(), "consumer lagging"))
+	assert.Equal(t, uint64(6), sampler.Dropped())
+}
+
+func TestSamplerCountsPerLevelAndMessage(t *testing.T) {
+	ulog, _, out := newTestSampledLogger(SamplingConfig{Interval: time.Hour, First: 1})
+
+	for i := 0; i < 3; i++ {
+		ulog.Info("message A")
+		ulog.Warn("message A")
+		ulog.Info("message B")
+	}
+
+	assert.Equal(t, 3, strings.Count(out.String(), "\n"))
+}
+
+func TestSamplerResetsEveryInterval(t *testing.T) {
+	ulog, sampler, out := newTestSampledLogger(SamplingConfig{Interval: time.Minute, First: 1})
+	now := time.Now()
+	sampler.now = func() time.Time { return now }
+
+	ulog.Info(testMsg)
+	ulog.Info(testMsg)
+	now = now.Add(time.Minute)
+	ulog.Info(testMsg)
+
+	assert.Equal(t, 2, strings.Count(out.String(), testMsg))
+	assert.Equal(t, uint64(1), sampler.Dropped())
+}
+
+func TestSamplerNeverSamplesErrorsAndMonitoringEvents(t *testing.T) {
+	ulog, sampler, out := newTestSampledLogger(SamplingConfig{Interval: time.Hour, First: 1})
+
+	for i := 0; i < 5; i++ {
+		ulog.Error("error message")
+		ulog.WithMonitoringEvent(testEvent, testTID, testContentType).Info("monitoring message")
+	}
+
+	assert.Equal(t, 5, strings.Count(out.String(), "error message"))
+	assert.Equal(t, 5, strings.Count(out.String(), "monitoring message"))
+	assert.Equal(t, uint64(0), sampler.Dropped())
+}
+
+func TestSampledOutEntriesDoNotReachHooks(t *testing.T) {
+	ulog, _, out := newTestSampledLogger(SamplingConfig{Interval: time.Hour, First: 1})
+	hook := test.NewLocal(ulog.Logger)
+
+	ulog.Info(testMsg)
+	ulog.WithField("attempt", 2).Info(testMsg)
+
+	assert.Equal(t, 1, strings.Count(out.String(), testMsg))
+	assert.Len(t, hook.Entries, 1)
+	assert.Equal(t, logrus.InfoLevel, hook.LastEntry().Level)
+}
+
+func TestSamplerIgnoresFormattingHooks(t *testing.T) {
+	ulog, sampler, out := newTestSampledLogger(SamplingConfig{Interval: time.Hour, First: 2})
+	ulog.AddHook(&formattingHook{})
+
+	ulog.Info(testMsg)
+	ulog.Info(testMsg)
+
+	assert.Equal(t, 2, strings.Count(out.String(), testMsg), "Formatting the entries in the hooks should not advance the counters")
+	assert.Equal(t, uint64(0), sampler.Dropped())
+}
+
+func TestSamplerLoggingMethods(t *testing.T) {
+	ulog, sampler, out := newTestSampledLogger(SamplingConfig{Interval: time.Hour, First: 1})
+
+	ulog.Debugf("debug %d", 1)
+	ulog.WithField("foo", "bar").Debugf("debug %d", 1)
+	ulog.Println("print", 1)
+	ulog.Infoln("print", 1)
+	ulog.Warning("warn")
+	ulog.WithTransactionID(testTID).Warnf("%s", "warn")
+
+	assert.Equal(t, 1, strings.Count(out.String(), `"msg":"debug 1"`))
+	assert.Equal(t, 1, strings.Count(out.String(), `"msg":"print 1"`))
+	assert.Equal(t, 1, strings.Count(out.String(), `"msg":"warn"`))
+	assert.Equal(t, uint64(3), sampler.Dropped())
+}
+
+func TestSamplerZeroConfig(t *testing.T) {
+	ulog, sampler, out := newTestSampledLogger(SamplingConfig{})
+
+	for i := 0; i < defaultSamplingFirst+1; i++ {
+		ulog.Info("sampled with zero config")
+	}
+
+	assert.Equal(t, defaultSamplingFirst, strings.Count(out.String(), "sampled with zero config"))
+	assert.Equal(t, uint64(1), sampler.Dropped())
+}
@@ -0,0 +1,214 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SlogHandler is a slog.Handler which logs the records through the UPPLogger it was created from.
+// This way the records follow the agreed UPP log format and reach the logger hooks.
+type SlogHandler struct {
+	ulog   *UPPLogger
+	fields map[string]interface{}
+	groups []string
+}
+
+// SlogHandler returns a slog.Handler producing the same log entries as the UPPLogger.
+// Use logger.TransactionID, logger.UUID, logger.ValidFlag, logger.MonitoringEvent and
+// logger.CategorisedEvent to add the UPP specific fields to the records.
+func (ulog *UPPLogger) SlogHandler() *SlogHandler {
+	return &SlogHandler{ulog: ulog, fields: map[string]interface{}{}}
+}
+
+// Enabled reports whether the UPPLogger logs records with the given level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.ulog.level() >= logrusLevel(level)
+}
+
+// Handle logs the record with the fields from the context (see WithContext), the handler attributes
+// and the record attributes. The record time is logged as the entry time.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := make(map[string]interface{}, len(h.fields)+r.NumAttrs())
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		h.addAttr(fields, h.groups, a)
+		return true
+	})
+
+	entry := h.ulog.WithContext(ctx).WithFields(fields)
+	if !r.Time.IsZero() {
+		entry = entry.WithTime(r.Time)
+	}
+
+	switch logrusLevel(r.Level) {
+	case logrus.DebugLevel:
+		entry.Debug(r.Message)
+	case logrus.InfoLevel:
+		entry.Info(r.Message)
+	case logrus.WarnLevel:
+		entry.Warn(r.Message)
+	default:
+		entry.Error(r.Message)
+	}
+	return nil
+}
+
+// WithAttrs returns a new SlogHandler which adds the attributes to every record.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(map[string]interface{}, len(h.fields)+len(attrs))
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	for _, a := range attrs {
+		h.addAttr(fields, h.groups, a)
+	}
+	return &SlogHandler{ulog: h.ulog, fields: fields, groups: h.groups}
+}
+
+// WithGroup returns a new SlogHandler which qualifies the keys of the following attributes with the group name.
+// The UPP specific fields are never qualified.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := make([]string, len(h.groups), len(h.groups)+1)
+	copy(groups, h.groups)
+	return &SlogHandler{ulog: h.ulog, fields: h.fields, groups: append(groups, name)}
+}
+
+func (h *SlogHandler) addAttr(fields map[string]interface{}, groups []string, a slog.Attr) {
+	if v, ok := a.Value.Any().(uppValue); ok && a.Value.Kind() == slog.KindLogValuer {
+		fields[h.ulog.keyConf.keyName(v.key)] = v.value
+		return
+	}
+
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			groups = append(groups[:len(groups):len(groups)], a.Key)
+		}
+		for _, ga := range a.Value.Group() {
+			h.addAttr(fields, groups, ga)
+		}
+		return
+	}
+
+	key := a.Key
+	for i := len(groups) - 1; i >= 0; i-- {
+		key = groups[i] + "." + key
+	}
+	fields[key] = a.Value.Any()
+}
+
+func logrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return logrus.DebugLevel
+	case level < slog.LevelWarn:
+		return logrus.InfoLevel
+	case level < slog.LevelError:
+		return logrus.WarnLevel
+	default:
+		return logrus.ErrorLevel
+	}
+}
+
+type uppKey int
+
+const (
+	uppKeyTransactionID uppKey = iota
+	uppKeyUUID
+	uppKeyIsValid
+	uppKeyEventName
+	uppKeyMonitoringEvent
+	uppKeyContentType
+	uppKeyEventCategory
+	uppKeyEventMsg
+)
+
+func (conf *KeyNamesConfig) keyName(key uppKey) string {
+	switch key {
+	case uppKeyTransactionID:
+		return conf.KeyTransactionID
+	case uppKeyUUID:
+		return conf.KeyUUID
+	case uppKeyIsValid:
+		return conf.KeyIsValid
+	case uppKeyEventName:
+		return conf.KeyEventName
+	case uppKeyMonitoringEvent:
+		return conf.KeyMonitoringEvent
+	case uppKeyContentType:
+		return conf.KeyContentType
+	case uppKeyEventCategory:
+		return conf.KeyEventCategory
+	default:
+		return conf.KeyEventMsg
+	}
+}
+
+// uppValue marks the value of an UPP specific attribute, so that SlogHandler can log it with the configured key name.
+// Other handlers resolve it to the plain string value.
+type uppValue struct {
+	key   uppKey
+	value string
+}
+
+func (v uppValue) LogValue() slog.Value {
+	return slog.StringValue(v.value)
+}
+
+func uppAttr(defaultKeyName string, key uppKey, value string) slog.Attr {
+	return slog.Any(defaultKeyName, uppValue{key: key, value: value})
+}
+
+// TransactionID returns slog attribute with the transaction id.
+func TransactionID(tid string) slog.Attr {
+	return uppAttr(DefaultKeyTransactionID, uppKeyTransactionID, tid)
+}
+
+// UUID returns slog attribute with the uuid.
+func UUID(uuid string) slog.Attr {
+	return uppAttr(DefaultKeyUUID, uppKeyUUID, uuid)
+}
+
+// ValidFlag returns slog attribute with the "is valid" flag.
+func ValidFlag(isValid bool) slog.Attr {
+	return uppAttr(DefaultKeyIsValid, uppKeyIsValid, strconv.FormatBool(isValid))
+}
+
+// MonitoringEvent returns slog attribute with the monitoring event fields.
+// The monitoring event fields are boolean valued monitoring event, event name, transaction id and content type.
+func MonitoringEvent(eventName, tid, contentType string) slog.Attr {
+	return slog.Attr{Key: "", Value: slog.GroupValue(
+		uppAttr(DefaultKeyMonitoringEvent, uppKeyMonitoringEvent, "true"),
+		uppAttr(DefaultKeyEventName, uppKeyEventName, eventName),
+		uppAttr(DefaultKeyContentType, uppKeyContentType, contentType),
+		TransactionID(tid),
+	)}
+}
+
+// CategorisedEvent returns slog attribute with the categorised event fields.
+// The categorised event fields are event name, event category, event message and transaction id.
+func CategorisedEvent(eventName, eventCategory, eventMsg, tid string) slog.Attr {
+	return slog.Attr{Key: "", Value: slog.GroupValue(
+		uppAttr(DefaultKeyEventName, uppKeyEventName, eventName),
+		uppAttr(DefaultKeyEventCategory, uppKeyEventCategory, eventCategory),
+		uppAttr(DefaultKeyEventMsg, uppKeyEventMsg, eventMsg),
+		TransactionID(tid),
+	)}
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
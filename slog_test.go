@@ -0,0 +1,119 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogHandlerOutputMatchesUPPLogger(t *testing.T) {
+	conf := KeyNamesConfig{KeyTransactionID: "test-trans-id", KeyEventName: "test-event-name-key"}
+	slogOut := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName, conf)
+	ulog.Out = slogOut
+	uppOut := new(bytes.Buffer)
+	expectedLog := NewUPPInfoLogger(testServiceName, conf)
+	expectedLog.Out = uppOut
+
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, testMsg, 0)
+	r.AddAttrs(MonitoringEvent(testEvent, testTID, testContentType), UUID("test-uuid"), ValidFlag(true),
+		slog.Any("error", errors.New(testErrMsg)))
+	err := ulog.SlogHandler().Handle(context.Background(), r)
+	require.NoError(t, err)
+
+	expectedLog.WithMonitoringEvent(testEvent, testTID, testContentType).
+		WithUUID("test-uuid").
+		WithValidFlag(true).
+		WithError(errors.New(testErrMsg)).
+		WithTime(r.Time).
+		Warn(testMsg)
+
+	assert.Equal(t, uppOut.String(), slogOut.String())
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	ulog := NewUPPLogger(testServiceName, "warning")
+	hook := test.NewLocal(ulog.Logger)
+	log := slog.New(ulog.SlogHandler())
+
+	log.Debug("debug message")
+	log.Info("info message")
+	assert.Empty(t, hook.Entries)
+
+	log.Warn("warn message")
+	log.Error("error message")
+	log.Log(context.Background(), slog.LevelError+4, "critical message")
+
+	require.Len(t, hook.Entries, 3)
+	assert.Equal(t, logrus.WarnLevel, hook.Entries[0].Level)
+	assert.Equal(t, logrus.ErrorLevel, hook.Entries[1].Level)
+	assert.Equal(t, logrus.ErrorLevel, hook.Entries[2].Level)
+	assert.Equal(t, "critical message", hook.Entries[2].Message)
+}
+
+func TestSlogHandlerAttrsAndGroups(t *testing.T) {
+	conf := KeyNamesConfig{KeyTransactionID: "test-trans-id"}
+	ulog := NewUPPInfoLogger(testServiceName, conf)
+	hook := test.NewLocal(ulog.Logger)
+
+	log := slog.New(ulog.SlogHandler()).With("system", "upp").WithGroup("request")
+	log.Info("a info message", TransactionID(testTID), slog.Int("status", 200),
+		slog.Group("headers", slog.String("accept", "application/json")), slog.Attr{})
+
+	require.Len(t, hook.Entries, 1)
+	data := hook.LastEntry().Data
+	assert.Len(t, data, 5)
+	assert.Equal(t, "upp", data["system"])
+	assert.Equal(t, testTID, data[conf.KeyTransactionID])
+	assert.Equal(t, int64(200), data["request.status"])
+	assert.Equal(t, "application/json", data["request.headers.accept"])
+	assert.Contains(t, data, DefaultKeyTime)
+}
+
+func TestSlogHandlerWithContext(t *testing.T) {
+	ulog := NewUPPInfoLogger(testServiceName)
+	hook := test.NewLocal(ulog.Logger)
+
+	ctx := ContextWithTransactionID(context.Background(), testTID)
+	slog.New(ulog.SlogHandler()).InfoContext(ctx, "a info message")
+
+	require.Len(t, hook.Entries, 1)
+	assert.Equal(t, testTID, hook.LastEntry().Data[DefaultKeyTransactionID])
+}
+
+func TestUPPAttrsWithOtherHandlers(t *testing.T) {
+	out := new(bytes.Buffer)
+	log := slog.New(slog.NewTextHandler(out, nil))
+
+	log.Info("a info message", MonitoringEvent(testEvent, testTID, testContentType), ValidFlag(false))
+
+	line := out.String()
+	assert.True(t, strings.Contains(line, "monitoring_event=true"), line)
+	assert.True(t, strings.Contains(line, "event="+testEvent), line)
+	assert.True(t, strings.Contains(line, "content_type="+testContentType), line)
+	assert.True(t, strings.Contains(line, "transaction_id="+testTID), line)
+	assert.True(t, strings.Contains(line, "isValid=false"), line)
+}
+
+func TestSlogHandlerReportCaller(t *testing.T) {
+	out := new(bytes.Buffer)
+	ulog := NewUPPInfoLogger(testServiceName)
+	ulog.Out = out
+	ulog.SetReportCaller(true)
+
+	slog.New(ulog.SlogHandler()).Info(testMsg)
+
+	assert.Contains(t, out.String(), `"function":"`+loggerPackage+`.TestSlogHandlerReportCaller"`)
+}
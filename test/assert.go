@@ -1,30 +1,102 @@
 package test
 
 import (
+	"regexp"
 	"strconv"
-	"testing"
 	"time"
 
+	logger "github.com/Financial-Times/go-logger/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
 // LoggingAssert struct exposes convenient assert methods for UPP logger specific log entries.
+// The failures are reported to t as errors, so all the assertions are checked even if one of them fails.
 type LoggingAssert struct {
-	t     *testing.T
-	entry *logrus.Entry
+	t       TestingT
+	entry   *logrus.Entry
+	keyConf *logger.KeyNamesConfig
 }
 
-func Assert(t *testing.T, entry *logrus.Entry) *LoggingAssert {
-	return &LoggingAssert{t, entry}
+// Assert returns LoggingAssert for entries logged with the default key names.
+func Assert(t TestingT, entry *logrus.Entry) *LoggingAssert {
+	return &LoggingAssert{t, entry, logger.GetDefaultKeyNamesConfig()}
 }
 
+// AssertWithKeys returns LoggingAssert for entries logged with the given key names,
+// e.g. the ones returned by UPPLogger.GetKeyNamesConfig.
+// The key names missing from keyConf are the default ones, as in the UPPLogger.
+func AssertWithKeys(t TestingT, entry *logrus.Entry, keyConf logger.KeyNamesConfig) *LoggingAssert {
+	return &LoggingAssert{t, entry, logger.GetFullKeyNameConfig(keyConf)}
+}
+
+// HasField asserts that the entry has the field with the value.
+// A nil value also matches a missing field, use NotHasField or HasFieldMatching to check the presence of the field.
 func (a *LoggingAssert) HasField(key string, value interface{}) *LoggingAssert {
-	assert.Equal(a.t, value, a.entry.Data[key])
+	a.t.Helper()
+	actual, found := a.entry.Data[key]
+	if !found && value != nil {
+		a.t.Errorf("Field %q not found, expected value %#v", key, value)
+		return a
+	}
+	if !assert.ObjectsAreEqual(value, actual) {
+		a.t.Errorf("Field %q has value %#v, expected %#v", key, actual, value)
+	}
+	return a
+}
+
+// NotHasField asserts that the entry does not have the field, whatever its value.
+func (a *LoggingAssert) NotHasField(key string) *LoggingAssert {
+	a.t.Helper()
+	if actual, found := a.entry.Data[key]; found {
+		a.t.Errorf("Field %q not expected, found with value %#v", key, actual)
+	}
+	return a
+}
+
+// HasFieldMatching asserts that the entry has the field with a value matching the matcher.
+func (a *LoggingAssert) HasFieldMatching(key string, matcher ValueMatcher) *LoggingAssert {
+	a.t.Helper()
+	value, found := a.entry.Data[key]
+	if !found {
+		a.t.Errorf("Field %q not found", key)
+		return a
+	}
+	if !matcher(value) {
+		a.t.Errorf("Field %q has unexpected value %#v", key, value)
+	}
+	return a
+}
+
+// HasLevel asserts that the entry was logged with the level.
+func (a *LoggingAssert) HasLevel(expectedLevel logrus.Level) *LoggingAssert {
+	a.t.Helper()
+	if a.entry.Level != expectedLevel {
+		a.t.Errorf("Entry has level %q, expected %q", a.entry.Level, expectedLevel)
+	}
+	return a
+}
+
+// HasMessage asserts that the entry was logged with the message.
+func (a *LoggingAssert) HasMessage(expectedMsg string) *LoggingAssert {
+	a.t.Helper()
+	if a.entry.Message != expectedMsg {
+		a.t.Errorf("Entry has message %q, expected %q", a.entry.Message, expectedMsg)
+	}
+	return a
+}
+
+// HasMessageMatching asserts that the entry message matches the regular expression.
+func (a *LoggingAssert) HasMessageMatching(regex string) *LoggingAssert {
+	a.t.Helper()
+	if !regexp.MustCompile(regex).MatchString(a.entry.Message) {
+		a.t.Errorf("Entry message %q does not match %q", a.entry.Message, regex)
+	}
 	return a
 }
 
 func (a *LoggingAssert) HasFields(fields map[string]interface{}) *LoggingAssert {
+	a.t.Helper()
 	for k, v := range fields {
 		a.HasField(k, v)
 	}
@@ -32,28 +104,34 @@ func (a *LoggingAssert) HasFields(fields map[string]interface{}) *LoggingAssert
 }
 
 func (a *LoggingAssert) HasMonitoringEvent(expectedEventName, expectedTID, expectedContentType string) *LoggingAssert {
-	return a.HasField("event", expectedEventName).
+	a.t.Helper()
+	return a.HasField(a.keyConf.KeyEventName, expectedEventName).
 		HasTransactionID(expectedTID).
-		HasField("content_type", expectedContentType).
-		HasField("monitoring_event", "true")
+		HasField(a.keyConf.KeyContentType, expectedContentType).
+		HasField(a.keyConf.KeyMonitoringEvent, "true")
 }
 
 func (a *LoggingAssert) HasValidFlag(expectedFlag bool) *LoggingAssert {
-	return a.HasField("isValid", strconv.FormatBool(expectedFlag))
+	a.t.Helper()
+	return a.HasField(a.keyConf.KeyIsValid, strconv.FormatBool(expectedFlag))
 }
 
 func (a *LoggingAssert) HasTransactionID(expectedTID string) *LoggingAssert {
-	return a.HasField("transaction_id", expectedTID)
+	a.t.Helper()
+	return a.HasField(a.keyConf.KeyTransactionID, expectedTID)
 }
 
 func (a *LoggingAssert) HasUUID(expectedUUID string) *LoggingAssert {
-	return a.HasField("uuid", expectedUUID)
+	a.t.Helper()
+	return a.HasField(a.keyConf.KeyUUID, expectedUUID)
 }
 
 func (a *LoggingAssert) HasTime(expectedTime time.Time) *LoggingAssert {
-	return a.HasField("time", expectedTime.Format(time.RFC3339Nano))
+	a.t.Helper()
+	return a.HasField(a.keyConf.KeyTime, expectedTime.Format(time.RFC3339Nano))
 }
 
 func (a *LoggingAssert) HasError(expectedErr error) *LoggingAssert {
-	return a.HasField(logrus.ErrorKey, expectedErr)
+	a.t.Helper()
+	return a.HasField(a.keyConf.KeyError, expectedErr)
 }
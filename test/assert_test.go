@@ -6,6 +6,7 @@ import (
 	"time"
 
 	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,16 @@ func TestAssertHasFieldFailed(t *testing.T) {
 	assert.True(t, mockT.Failed())
 }
 
+func TestAssertHasFieldNilForMissingField(t *testing.T) {
+	mockT := new(testing.T)
+	ulog := logger.NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	ulog.Info()
+	e := hook.LastEntry()
+	Assert(mockT, e).HasField("foo", nil).HasError(nil)
+	assert.False(t, mockT.Failed())
+}
+
 func TestAssertHasError(t *testing.T) {
 	mockT := new(testing.T)
 	ulog := logger.NewUPPInfoLogger("test_service")
@@ -227,3 +238,109 @@ func TestNoDataRace(t *testing.T) {
 	require.NotNil(t, hook.LastEntry())
 	assert.Equal(t, "info", hook.LastEntry().Level.String())
 }
+
+func TestAssertWithKeysHasMonitoringEvent(t *testing.T) {
+	mockT := new(testing.T)
+	conf := logger.KeyNamesConfig{
+		KeyTransactionID:   "test-transaction-id-key",
+		KeyEventName:       "test-event-name-key",
+		KeyMonitoringEvent: "test-monitoring-key",
+		KeyContentType:     "test-content-type-key",
+	}
+	ulog := logger.NewUPPInfoLogger("test_service", conf)
+	hook := test.NewLocal(ulog.Logger)
+	ulog.WithMonitoringEvent("anEvent", "tid_test", "aContentType").Info()
+	e := hook.LastEntry()
+	AssertWithKeys(mockT, e, ulog.GetKeyNamesConfig()).HasMonitoringEvent("anEvent", "tid_test", "aContentType")
+	assert.False(t, mockT.Failed())
+}
+
+func TestAssertWithDefaultKeysFailsForCustomKeys(t *testing.T) {
+	mockT := new(testing.T)
+	conf := logger.KeyNamesConfig{KeyTransactionID: "test-transaction-id-key"}
+	ulog := logger.NewUPPInfoLogger("test_service", conf)
+	hook := test.NewLocal(ulog.Logger)
+	ulog.WithTransactionID("tid_test").Info()
+	e := hook.LastEntry()
+	Assert(mockT, e).HasTransactionID("tid_test")
+	assert.True(t, mockT.Failed())
+}
+
+func TestAssertWithKeysPartialConf(t *testing.T) {
+	mockT := new(testing.T)
+	conf := logger.KeyNamesConfig{KeyUUID: "test-uuid-key", KeyError: "test-error-key"}
+	ulog := logger.NewUPPInfoLogger("test_service", conf)
+	hook := test.NewLocal(ulog.Logger)
+	ulog.WithTransactionID("tid_test").WithUUID("test-uuid").WithValidFlag(true).WithError(assert.AnError).Error()
+	e := hook.LastEntry()
+	AssertWithKeys(mockT, e, conf).
+		HasTransactionID("tid_test").
+		HasUUID("test-uuid").
+		HasValidFlag(true).
+		HasError(assert.AnError)
+	assert.False(t, mockT.Failed())
+}
+
+func TestAssertNotHasField(t *testing.T) {
+	mockT := new(testing.T)
+	ulog := logger.NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	ulog.WithField("foo", "bar").Info()
+	e := hook.LastEntry()
+	Assert(mockT, e).NotHasField("bar")
+	assert.False(t, mockT.Failed())
+	Assert(mockT, e).NotHasField("foo")
+	assert.True(t, mockT.Failed())
+}
+
+func TestAssertHasFieldMatching(t *testing.T) {
+	mockT := new(testing.T)
+	ulog := logger.NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	ulog.WithField("tid", "tid_a1b2c3").Info()
+	e := hook.LastEntry()
+	Assert(mockT, e).HasFieldMatching("tid", MatchesRegexp("^tid_[a-z0-9]+$"))
+	assert.False(t, mockT.Failed())
+	Assert(mockT, e).HasFieldMatching("tid", func(v interface{}) bool { return v == "other" })
+	assert.True(t, mockT.Failed())
+}
+
+func TestAssertHasFieldMatchingMissingField(t *testing.T) {
+	mockT := new(testing.T)
+	ulog := logger.NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	ulog.Info()
+	Assert(mockT, hook.LastEntry()).HasFieldMatching("tid", func(interface{}) bool { return true })
+	assert.True(t, mockT.Failed())
+}
+
+func TestAssertHasLevelAndMessage(t *testing.T) {
+	mockT := new(testing.T)
+	ulog := logger.NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	ulog.Warn("Content 123 was skipped")
+	e := hook.LastEntry()
+	Assert(mockT, e).
+		HasLevel(logrus.WarnLevel).
+		HasMessage("Content 123 was skipped").
+		HasMessageMatching(`^Content \d+ was skipped$`)
+	assert.False(t, mockT.Failed())
+}
+
+func TestAssertHasLevelFailed(t *testing.T) {
+	mockT := new(testing.T)
+	ulog := logger.NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	ulog.Warn("a message")
+	Assert(mockT, hook.LastEntry()).HasLevel(logrus.ErrorLevel)
+	assert.True(t, mockT.Failed())
+}
+
+func TestAssertHasMessageFailed(t *testing.T) {
+	mockT := new(testing.T)
+	ulog := logger.NewUPPInfoLogger("test_service")
+	hook := test.NewLocal(ulog.Logger)
+	ulog.Info("a message")
+	Assert(mockT, hook.LastEntry()).HasMessageMatching("^another")
+	assert.True(t, mockT.Failed())
+}
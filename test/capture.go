@@ -0,0 +1,170 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sync"
+
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// FieldMatcher reports whether the fields of a log entry match a condition.
+type FieldMatcher func(fields logrus.Fields) bool
+
+// Field returns FieldMatcher matching the entries with the field equal to the value.
+func Field(key string, value interface{}) FieldMatcher {
+	return func(fields logrus.Fields) bool {
+		actual, found := fields[key]
+		return found && reflect.DeepEqual(value, actual)
+	}
+}
+
+// HasKey returns FieldMatcher matching the entries with the field, whatever its value.
+func HasKey(key string) FieldMatcher {
+	return func(fields logrus.Fields) bool {
+		_, found := fields[key]
+		return found
+	}
+}
+
+// ValueMatcher reports whether a field value matches a condition.
+type ValueMatcher func(value interface{}) bool
+
+// MatchesRegexp returns ValueMatcher matching the values whose string representation matches the regular expression.
+func MatchesRegexp(regex string) ValueMatcher {
+	re := regexp.MustCompile(regex)
+	return func(value interface{}) bool {
+		return re.MatchString(fmt.Sprint(value))
+	}
+}
+
+// Capture records the entries logged by an UPPLogger and their serialized form as written to the logger output.
+type Capture struct {
+	keyConf    logger.KeyNamesConfig
+	mu         sync.RWMutex
+	entries    []*logrus.Entry
+	serialized [][]byte
+}
+
+// NewCapture installs a hook recording the entries logged by ulog and wraps its output
+// to record the serialized entries as well. The output is still written to the original writer.
+func NewCapture(ulog *logger.UPPLogger) *Capture {
+	c := &Capture{keyConf: ulog.GetKeyNamesConfig()}
+	ulog.AddHook(c)
+	ulog.Out = &captureWriter{out: ulog.Out, capture: c}
+	return c
+}
+
+// KeyNamesConfig returns the key names config of the captured logger.
+func (c *Capture) KeyNamesConfig() logger.KeyNamesConfig {
+	return c.keyConf
+}
+
+// Levels implements logrus.Hook.
+func (c *Capture) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (c *Capture) Fire(e *logrus.Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, e)
+	return nil
+}
+
+// Entries returns copies of all the captured entries.
+func (c *Capture) Entries() []*logrus.Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries := make([]*logrus.Entry, len(c.entries))
+	for i, entry := range c.entries {
+		e := *entry
+		entries[i] = &e
+	}
+	return entries
+}
+
+// LastEntry returns a copy of the last captured entry or nil if there is none.
+func (c *Capture) LastEntry() *logrus.Entry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.entries) == 0 {
+		return nil
+	}
+	e := *c.entries[len(c.entries)-1]
+	return &e
+}
+
+// Filter returns the captured entries with the given level matching all the field matchers.
+func (c *Capture) Filter(level logrus.Level, matchers ...FieldMatcher) []*logrus.Entry {
+	var filtered []*logrus.Entry
+	for _, e := range c.Entries() {
+		if e.Level == level && matchAll(e.Data, matchers) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// FindMonitoringEvent returns the first captured monitoring event with the given event name or nil if there is none.
+func (c *Capture) FindMonitoringEvent(eventName string) *logrus.Entry {
+	matchers := []FieldMatcher{
+		Field(c.keyConf.KeyMonitoringEvent, "true"),
+		Field(c.keyConf.KeyEventName, eventName),
+	}
+	for _, e := range c.Entries() {
+		if matchAll(e.Data, matchers) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Serialized returns copies of the serialized entries written to the logger output, one per entry.
+func (c *Capture) Serialized() [][]byte {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	serialized := make([][]byte, len(c.serialized))
+	for i, s := range c.serialized {
+		serialized[i] = append([]byte(nil), s...)
+	}
+	return serialized
+}
+
+// Reset removes all the captured entries.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	c.serialized = nil
+}
+
+func (c *Capture) write(p []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.serialized = append(c.serialized, append([]byte(nil), p...))
+}
+
+func matchAll(fields logrus.Fields, matchers []FieldMatcher) bool {
+	for _, m := range matchers {
+		if !m(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+// captureWriter records the serialized entries before writing them to the original output.
+type captureWriter struct {
+	out     io.Writer
+	capture *Capture
+}
+
+func (w *captureWriter) Write(p []byte) (int, error) {
+	w.capture.write(p)
+	return w.out.Write(p)
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCapture() (*logger.UPPLogger, *Capture) {
+	ulog := logger.NewUPPInfoLogger("test_service")
+	ulog.Out = ioutil.Discard
+	return ulog, NewCapture(ulog)
+}
+
+func TestCaptureEntries(t *testing.T) {
+	ulog, capture := newTestCapture()
+	assert.Nil(t, capture.LastEntry())
+
+	ulog.WithTransactionID("tid_test").Info("first")
+	ulog.Warn("second")
+
+	entries := capture.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first", entries[0].Message)
+	assert.Equal(t, "tid_test", entries[0].Data[logger.DefaultKeyTransactionID])
+	assert.Equal(t, "second", capture.LastEntry().Message)
+	assert.Equal(t, logrus.WarnLevel, capture.LastEntry().Level)
+}
+
+func TestCaptureSerialized(t *testing.T) {
+	ulog, capture := newTestCapture()
+
+	ulog.WithTransactionID("tid_test").WithError(assert.AnError).Error("failed")
+
+	serialized := capture.Serialized()
+	require.Len(t, serialized, 1)
+	var logLine map[string]string
+	require.NoError(t, json.Unmarshal(serialized[0], &logLine))
+	assert.Equal(t, "test_service", logLine[logger.DefaultKeyServiceName])
+	assert.Equal(t, "tid_test", logLine[logger.DefaultKeyTransactionID])
+	assert.Equal(t, assert.AnError.Error(), logLine[logger.DefaultKeyError])
+	assert.Equal(t, "failed", logLine[logger.DefaultKeyMsg])
+}
+
+func TestCaptureSkipsSampledOutEntries(t *testing.T) {
+	ulog, capture := newTestCapture()
+	ulog.SetSampler(logger.NewSampler(logger.SamplingConfig{Interval: time.Hour, First: 1}))
+
+	ulog.Info("repeated")
+	ulog.Info("repeated")
+
+	assert.Len(t, capture.Entries(), 1)
+	assert.Len(t, capture.Serialized(), 1)
+}
+
+func TestCaptureFilter(t *testing.T) {
+	ulog, capture := newTestCapture()
+
+	ulog.WithTransactionID("tid_1").WithField("foo", "bar").Info("first")
+	ulog.WithTransactionID("tid_2").WithField("foo", "bar").Info("second")
+	ulog.WithTransactionID("tid_1").Warn("third")
+
+	filtered := capture.Filter(logrus.InfoLevel, HasKey("foo"))
+	require.Len(t, filtered, 2)
+
+	filtered = capture.Filter(logrus.InfoLevel, Field(logger.DefaultKeyTransactionID, "tid_1"), Field("foo", "bar"))
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "first", filtered[0].Message)
+
+	assert.Empty(t, capture.Filter(logrus.ErrorLevel))
+}
+
+func TestCaptureFindMonitoringEvent(t *testing.T) {
+	conf := logger.KeyNamesConfig{KeyEventName: "test-event-name-key"}
+	ulog := logger.NewUPPInfoLogger("test_service", conf)
+	ulog.Out = ioutil.Discard
+	capture := NewCapture(ulog)
+
+	ulog.WithCategorisedEvent("Map", "category", "msg", "tid_0").Info("not a monitoring event")
+	ulog.WithMonitoringEvent("Map", "tid_1", "Annotations").Info("mapped")
+	ulog.WithMonitoringEvent("Map", "tid_2", "Annotations").Info("mapped")
+
+	e := capture.FindMonitoringEvent("Map")
+	require.NotNil(t, e)
+	AssertWithKeys(t, e, conf).HasMonitoringEvent("Map", "tid_1", "Annotations")
+
+	assert.Nil(t, capture.FindMonitoringEvent("Publish"))
+}
+
+func TestCaptureReset(t *testing.T) {
+	ulog, capture := newTestCapture()
+
+	ulog.Info("first")
+	capture.Reset()
+	ulog.Info("second")
+
+	require.Len(t, capture.Entries(), 1)
+	assert.Equal(t, "second", capture.LastEntry().Message)
+	assert.Len(t, capture.Serialized(), 1)
+}
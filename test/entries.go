@@ -0,0 +1,81 @@
+package test
+
+import (
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// EntriesAssert exposes assert methods for a sequence of log entries, e.g. all the entries logged during a test.
+type EntriesAssert struct {
+	t       TestingT
+	entries []*logrus.Entry
+	keyConf *logger.KeyNamesConfig
+}
+
+// AssertEntries returns EntriesAssert for entries logged with the default key names.
+func AssertEntries(t TestingT, entries []*logrus.Entry) *EntriesAssert {
+	return &EntriesAssert{t, entries, logger.GetDefaultKeyNamesConfig()}
+}
+
+// AssertEntriesWithKeys returns EntriesAssert for entries logged with the given key names.
+func AssertEntriesWithKeys(t TestingT, entries []*logrus.Entry, keyConf logger.KeyNamesConfig) *EntriesAssert {
+	return &EntriesAssert{t, entries, logger.GetFullKeyNameConfig(keyConf)}
+}
+
+// AssertEntries returns EntriesAssert for the captured entries with the key names of the captured logger.
+func (c *Capture) AssertEntries(t TestingT) *EntriesAssert {
+	t.Helper()
+	return AssertEntriesWithKeys(t, c.Entries(), c.keyConf)
+}
+
+// HasCount asserts that exactly n entries match all the field matchers.
+func (a *EntriesAssert) HasCount(n int, matchers ...FieldMatcher) *EntriesAssert {
+	a.t.Helper()
+	if count := a.count(matchers); count != n {
+		a.t.Errorf("Found %d matching log entries, expected %d", count, n)
+	}
+	return a
+}
+
+// HasMonitoringEventCount asserts that exactly n monitoring events were logged for the transaction ID.
+func (a *EntriesAssert) HasMonitoringEventCount(tid string, n int) *EntriesAssert {
+	a.t.Helper()
+	count := a.count([]FieldMatcher{
+		Field(a.keyConf.KeyMonitoringEvent, "true"),
+		Field(a.keyConf.KeyTransactionID, tid),
+	})
+	if count != n {
+		a.t.Errorf("Found %d monitoring events for transaction ID %q, expected %d", count, tid, n)
+	}
+	return a
+}
+
+// HasEventsInOrder asserts that the events with the given names were logged in this order,
+// i.e. that each event was logged after the previous one. Other entries may be logged in between.
+func (a *EntriesAssert) HasEventsInOrder(eventNames ...string) *EntriesAssert {
+	a.t.Helper()
+	i := 0
+	for _, eventName := range eventNames {
+		matcher := Field(a.keyConf.KeyEventName, eventName)
+		for i < len(a.entries) && !matcher(a.entries[i].Data) {
+			i++
+		}
+		if i == len(a.entries) {
+			a.t.Errorf("Events not logged in the expected order: event %q not found after the previous events in %q",
+				eventName, eventNames)
+			return a
+		}
+		i++
+	}
+	return a
+}
+
+func (a *EntriesAssert) count(matchers []FieldMatcher) int {
+	count := 0
+	for _, e := range a.entries {
+		if matchAll(e.Data, matchers) {
+			count++
+		}
+	}
+	return count
+}
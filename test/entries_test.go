@@ -0,0 +1,88 @@
+package test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func logPublishLifecycle(ulog *logger.UPPLogger) {
+	ulog.WithMonitoringEvent("Received", "tid_1", "Article").Info("received")
+	ulog.WithTransactionID("tid_1").Info("processing")
+	ulog.WithMonitoringEvent("Received", "tid_2", "Article").Info("received")
+	ulog.WithMonitoringEvent("Mapped", "tid_1", "Article").Info("mapped")
+	ulog.WithMonitoringEvent("Published", "tid_1", "Article").Info("published")
+}
+
+func TestEntriesAssertHasCount(t *testing.T) {
+	mockT := new(testing.T)
+	ulog, capture := newTestCapture()
+	logPublishLifecycle(ulog)
+
+	AssertEntries(mockT, capture.Entries()).
+		HasCount(5).
+		HasCount(4, HasKey(logger.DefaultKeyMonitoringEvent)).
+		HasCount(2, Field(logger.DefaultKeyEventName, "Received"))
+	assert.False(t, mockT.Failed())
+
+	AssertEntries(mockT, capture.Entries()).HasCount(1, Field(logger.DefaultKeyEventName, "Received"))
+	assert.True(t, mockT.Failed())
+}
+
+func TestEntriesAssertHasMonitoringEventCount(t *testing.T) {
+	mockT := new(testing.T)
+	ulog, capture := newTestCapture()
+	logPublishLifecycle(ulog)
+
+	capture.AssertEntries(mockT).
+		HasMonitoringEventCount("tid_1", 3).
+		HasMonitoringEventCount("tid_2", 1).
+		HasMonitoringEventCount("tid_3", 0)
+	assert.False(t, mockT.Failed())
+
+	capture.AssertEntries(mockT).HasMonitoringEventCount("tid_1", 4)
+	assert.True(t, mockT.Failed())
+}
+
+func TestEntriesAssertHasMonitoringEventCountWithKeys(t *testing.T) {
+	mockT := new(testing.T)
+	conf := logger.KeyNamesConfig{KeyTransactionID: "test-transaction-id-key"}
+	ulog := logger.NewUPPInfoLogger("test_service", conf)
+	ulog.Out = ioutil.Discard
+	capture := NewCapture(ulog)
+	logPublishLifecycle(ulog)
+
+	capture.AssertEntries(mockT).HasMonitoringEventCount("tid_1", 3)
+	assert.False(t, mockT.Failed())
+}
+
+func TestEntriesAssertHasEventsInOrder(t *testing.T) {
+	mockT := new(testing.T)
+	ulog, capture := newTestCapture()
+	logPublishLifecycle(ulog)
+
+	capture.AssertEntries(mockT).
+		HasEventsInOrder("Received", "Mapped", "Published").
+		HasEventsInOrder("Received", "Published")
+	assert.False(t, mockT.Failed())
+}
+
+func TestEntriesAssertHasEventsInOrderFailed(t *testing.T) {
+	mockT := new(testing.T)
+	ulog, capture := newTestCapture()
+	logPublishLifecycle(ulog)
+
+	capture.AssertEntries(mockT).HasEventsInOrder("Published", "Mapped")
+	assert.True(t, mockT.Failed())
+}
+
+func TestEntriesAssertHasEventsInOrderMissingEvent(t *testing.T) {
+	mockT := new(testing.T)
+	ulog, capture := newTestCapture()
+	logPublishLifecycle(ulog)
+
+	capture.AssertEntries(mockT).HasEventsInOrder("Received", "Deleted")
+	assert.True(t, mockT.Failed())
+}
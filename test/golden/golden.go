@@ -0,0 +1,139 @@
+// Package golden compares the serialized log output captured with test.Capture with golden JSON-lines files.
+// Run the tests with the -update flag to create or update the golden files with the current output.
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/Financial-Times/go-logger/v2/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// VolatileValue replaces the values of the volatile fields in the normalised output.
+const VolatileValue = "<volatile>"
+
+const updateFlag = "update"
+
+// FrozenTime is the time of the entries logged by the loggers with FrozenClock.
+var FrozenTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func init() {
+	// The flag may already be defined by another golden file helper, then its value is used
+	if flag.Lookup(updateFlag) == nil {
+		flag.Bool(updateFlag, false, "update the golden files with the current log output")
+	}
+}
+
+// FrozenClock returns FrozenTime. The loggers created with logger.WithClock(FrozenClock) format all the entries
+// with the frozen time, so that their output can be compared with the golden files.
+func FrozenClock() time.Time {
+	return FrozenTime
+}
+
+// NewLogger returns UPP logger created with logger.New, the options and the frozen clock,
+// together with the capture of its output to be passed to Assert.
+func NewLogger(serviceName string, opts ...logger.Option) (*logger.UPPLogger, *test.Capture, error) {
+	ulog, err := logger.New(serviceName, append(opts, logger.WithClock(FrozenClock))...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ulog, test.NewCapture(ulog), nil
+}
+
+// Assert compares the normalised log output captured by capture with the golden file.
+// With the -update flag, it writes the normalised output to the golden file instead.
+//
+// The captured logger should be created with the frozen clock, e.g. with NewLogger, so that the entries are formatted
+// with FrozenTime. The values of the fields which change from run to run, i.e. the caller, function, duration
+// and error stack trace fields and the given volatile keys, are replaced with VolatileValue.
+// Any other difference, such as a renamed or a missing field, fails the test.
+func Assert(t testing.TB, capture *test.Capture, goldenFile string, volatileKeys ...string) {
+	t.Helper()
+	actual, err := Normalise(capture.Serialized(), capture.KeyNamesConfig(), volatileKeys...)
+	require.NoError(t, err, "Failed to normalise the log output")
+
+	if update() {
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFile), 0755))
+		require.NoError(t, ioutil.WriteFile(goldenFile, actual, 0644))
+		t.Logf("Updated golden file %s", goldenFile)
+		return
+	}
+
+	expected, err := ioutil.ReadFile(goldenFile)
+	require.NoError(t, err, "Failed to read the golden file, run the test with -update to create it")
+	assert.Equal(t, string(expected), string(actual), "Log output differs from golden file %s", goldenFile)
+}
+
+// update reports whether the -update flag is set.
+func update() bool {
+	getter, ok := flag.Lookup(updateFlag).Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+	set, _ := getter.Get().(bool)
+	return set
+}
+
+// Normalise replaces the volatile values in the serialized entries, keeping the order of their fields,
+// and returns them as JSON lines.
+func Normalise(serialized [][]byte, keyConf logger.KeyNamesConfig, volatileKeys ...string) ([]byte, error) {
+	conf := logger.GetFullKeyNameConfig(keyConf)
+	volatile := map[string]bool{conf.KeyCaller: true, conf.KeyFunction: true, conf.KeyDuration: true}
+	for _, k := range volatileKeys {
+		volatile[k] = true
+	}
+	volatileValue := jsonString(VolatileValue)
+
+	out := new(bytes.Buffer)
+	for i, line := range serialized {
+		dec := json.NewDecoder(bytes.NewReader(line))
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+			return nil, fmt.Errorf("entry %d is not a JSON object: %s", i, line)
+		}
+		out.WriteByte('{')
+		for first := true; dec.More(); first = false {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("entry %d is not valid JSON: %v", i, err)
+			}
+			key := tok.(string)
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, fmt.Errorf("entry %d is not valid JSON: %v", i, err)
+			}
+
+			if volatile[key] || strings.HasSuffix(key, "_stack") {
+				value = volatileValue
+			}
+
+			if !first {
+				out.WriteByte(',')
+			}
+			out.Write(jsonString(key))
+			out.WriteByte(':')
+			out.Write(value)
+		}
+		out.WriteString("}\n")
+	}
+	return out.Bytes(), nil
+}
+
+// jsonString encodes the string as JSON without escaping the HTML characters, e.g. < and >.
+func jsonString(s string) []byte {
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
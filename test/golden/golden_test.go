@@ -0,0 +1,59 @@
+package golden
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertGolden(t *testing.T) {
+	conf := logger.KeyNamesConfig{KeyTransactionID: "test-transaction-id-key"}
+	ulog, capture, err := NewLogger("test_service", logger.WithKeyNames(conf), logger.WithOutput(ioutil.Discard), logger.WithReportCaller())
+	require.NoError(t, err)
+
+	ulog.WithMonitoringEvent("Map", "tid_test", "Annotations").WithUUID("test-uuid").Info("Successfully mapped")
+	ulog.WithMonitoringEventFields(logger.MonitoringEventFields{
+		EventName:     "Publish",
+		TransactionID: "tid_test",
+		ContentType:   "Annotations",
+		Duration:      time.Duration(time.Now().UnixNano() % int64(time.Second)),
+	}).WithError(logger.Errorf("publish failed: %w", errors.New("timeout"))).Error("Failed to publish")
+	ulog.WithField("request_id", time.Now().UnixNano()).Warn("Slow request")
+
+	Assert(t, capture, "testdata/service.golden", "request_id")
+}
+
+func TestNormaliseKeepsFieldOrder(t *testing.T) {
+	serialized := [][]byte{
+		[]byte(`{"time":"2020-05-06T07:08:09Z","level":"info","msg":"a \"quoted\" message","nested":{"b":1,"a":[true,null]},"caller":"main.go:12"}` + "\n"),
+	}
+
+	actual, err := Normalise(serialized, logger.KeyNamesConfig{})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"time":"2020-05-06T07:08:09Z","level":"info","msg":"a \"quoted\" message","nested":{"b":1,"a":[true,null]},"caller":"<volatile>"}`+"\n", string(actual))
+}
+
+func TestNewLoggerFreezesTime(t *testing.T) {
+	ulog, capture, err := NewLogger("test_service", logger.WithOutput(ioutil.Discard))
+	require.NoError(t, err)
+
+	ulog.Info("Frozen")
+
+	serialized := capture.Serialized()
+	require.Len(t, serialized, 1)
+	assert.Contains(t, string(serialized[0]), `"time":"`+FrozenTime.Format(time.RFC3339Nano)+`"`)
+}
+
+func TestNormaliseInvalidOutput(t *testing.T) {
+	_, err := Normalise([][]byte{[]byte("level=info msg=text")}, logger.KeyNamesConfig{})
+	assert.Error(t, err)
+
+	_, err = Normalise([][]byte{[]byte(`{"level":`)}, logger.KeyNamesConfig{})
+	assert.Error(t, err)
+}
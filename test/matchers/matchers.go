@@ -0,0 +1,170 @@
+// Package matchers provides Gomega matchers for the entries logged by the UPP logger.
+//
+// The entry matchers (HaveUPPField, HaveTransactionID, HaveMonitoringEvent) match *logrus.Entry values.
+// ContainLogEntry matches the entries captured with test.Capture, so it can be used with Eventually
+// for code logging asynchronously:
+//
+//	capture := test.NewCapture(ulog)
+//	...
+//	Eventually(capture.Entries).Should(ContainLogEntry(HaveMonitoringEvent("Map", tid, "Annotations")))
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/Financial-Times/go-logger/v2/test"
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/sirupsen/logrus"
+)
+
+// HaveUPPField succeeds if the entry has the field with the expected value.
+// The expected value can also be a Gomega matcher, e.g. HaveUPPField("status", BeNumerically(">=", 500)).
+func HaveUPPField(key string, expected interface{}) types.GomegaMatcher {
+	return &fieldMatcher{key: key, expected: expected}
+}
+
+// HaveTransactionID succeeds if the entry has the transaction ID.
+// The key names config can be passed for entries logged with custom key names, as in NewUPPLogger.
+func HaveTransactionID(tid string, kconf ...logger.KeyNamesConfig) types.GomegaMatcher {
+	return HaveUPPField(keyNames(kconf).KeyTransactionID, tid)
+}
+
+// HaveUUID succeeds if the entry has the UUID.
+func HaveUUID(uuid string, kconf ...logger.KeyNamesConfig) types.GomegaMatcher {
+	return HaveUPPField(keyNames(kconf).KeyUUID, uuid)
+}
+
+// HaveValidFlag succeeds if the entry has the "is valid" flag.
+func HaveValidFlag(isValid bool, kconf ...logger.KeyNamesConfig) types.GomegaMatcher {
+	return HaveUPPField(keyNames(kconf).KeyIsValid, strconv.FormatBool(isValid))
+}
+
+// HaveMonitoringEvent succeeds if the entry is the monitoring event with the event name, transaction ID and content type.
+func HaveMonitoringEvent(eventName, tid, contentType string, kconf ...logger.KeyNamesConfig) types.GomegaMatcher {
+	conf := keyNames(kconf)
+	return gomega.SatisfyAll(
+		HaveUPPField(conf.KeyMonitoringEvent, "true"),
+		HaveUPPField(conf.KeyEventName, eventName),
+		HaveUPPField(conf.KeyTransactionID, tid),
+		HaveUPPField(conf.KeyContentType, contentType),
+	)
+}
+
+// ContainLogEntry succeeds if any of the entries satisfies all the matchers.
+// The entries can be []*logrus.Entry or *test.Capture.
+func ContainLogEntry(matchers ...types.GomegaMatcher) types.GomegaMatcher {
+	return &containEntryMatcher{matcher: gomega.SatisfyAll(matchers...)}
+}
+
+func keyNames(kconf []logger.KeyNamesConfig) *logger.KeyNamesConfig {
+	if len(kconf) > 0 {
+		return logger.GetFullKeyNameConfig(kconf[0])
+	}
+	return logger.GetDefaultKeyNamesConfig()
+}
+
+type fieldMatcher struct {
+	key      string
+	expected interface{}
+}
+
+func (m *fieldMatcher) Match(actual interface{}) (bool, error) {
+	entry, err := toEntry(actual)
+	if err != nil {
+		return false, err
+	}
+	value, found := entry.Data[m.key]
+	if !found {
+		return false, nil
+	}
+	if matcher, ok := m.expected.(types.GomegaMatcher); ok {
+		return matcher.Match(value)
+	}
+	return reflect.DeepEqual(m.expected, value), nil
+}
+
+func (m *fieldMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%s\nto have field %q with value %s", describe(actual), m.key, m.describeExpected())
+}
+
+func (m *fieldMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%s\nnot to have field %q with value %s", describe(actual), m.key, m.describeExpected())
+}
+
+func (m *fieldMatcher) describeExpected() string {
+	if _, ok := m.expected.(types.GomegaMatcher); ok {
+		return fmt.Sprintf("matching %T", m.expected)
+	}
+	return fmt.Sprintf("%#v", m.expected)
+}
+
+type containEntryMatcher struct {
+	matcher types.GomegaMatcher
+}
+
+func (m *containEntryMatcher) Match(actual interface{}) (bool, error) {
+	entries, err := toEntries(actual)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		matches, err := m.matcher.Match(e)
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *containEntryMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%s\nto contain a log entry satisfying the matchers", describe(actual))
+}
+
+func (m *containEntryMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("Expected\n\t%s\nnot to contain a log entry satisfying the matchers", describe(actual))
+}
+
+func toEntry(actual interface{}) (*logrus.Entry, error) {
+	entry, ok := actual.(*logrus.Entry)
+	if !ok || entry == nil {
+		return nil, fmt.Errorf("expected a non-nil *logrus.Entry, got %T", actual)
+	}
+	return entry, nil
+}
+
+func toEntries(actual interface{}) ([]*logrus.Entry, error) {
+	switch a := actual.(type) {
+	case []*logrus.Entry:
+		return a, nil
+	case *test.Capture:
+		return a.Entries(), nil
+	}
+	return nil, fmt.Errorf("expected []*logrus.Entry or *test.Capture, got %T", actual)
+}
+
+// describe formats the entries without their logger, which would only clutter the failure messages.
+func describe(actual interface{}) string {
+	switch a := actual.(type) {
+	case *logrus.Entry:
+		if a == nil {
+			return "nil entry"
+		}
+		return fmt.Sprintf("entry {level: %s, msg: %q, fields: %v}", a.Level, a.Message, a.Data)
+	case *test.Capture:
+		return describe(a.Entries())
+	case []*logrus.Entry:
+		s := fmt.Sprintf("%d entries", len(a))
+		for _, e := range a {
+			s += "\n\t" + describe(e)
+		}
+		return s
+	}
+	return fmt.Sprintf("%#v", actual)
+}
@@ -0,0 +1,107 @@
+package matchers
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	logger "github.com/Financial-Times/go-logger/v2"
+	"github.com/Financial-Times/go-logger/v2/test"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestCapture(kconf ...logger.KeyNamesConfig) (*logger.UPPLogger, *test.Capture) {
+	ulog := logger.NewUPPInfoLogger("test_service", kconf...)
+	ulog.Out = ioutil.Discard
+	return ulog, test.NewCapture(ulog)
+}
+
+func TestHaveUPPField(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ulog, capture := newTestCapture()
+
+	ulog.WithField("status", 503).WithField("foo", "bar").Error("failed")
+	e := capture.LastEntry()
+
+	g.Expect(e).To(HaveUPPField("foo", "bar"))
+	g.Expect(e).To(HaveUPPField("status", BeNumerically(">=", 500)))
+	g.Expect(e).NotTo(HaveUPPField("foo", "baz"))
+	g.Expect(e).NotTo(HaveUPPField("missing", "bar"))
+}
+
+func TestHaveUPPFieldFailureMessage(t *testing.T) {
+	ulog, capture := newTestCapture()
+	ulog.WithField("foo", "bar").Info("a message")
+
+	m := HaveUPPField("foo", "baz")
+	matches, err := m.Match(capture.LastEntry())
+
+	g := NewGomegaWithT(t)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(matches).To(BeFalse())
+	g.Expect(m.FailureMessage(capture.LastEntry())).To(ContainSubstring(`to have field "foo" with value "baz"`))
+	g.Expect(m.FailureMessage(capture.LastEntry())).To(ContainSubstring(`msg: "a message"`))
+}
+
+func TestHaveUPPFieldInvalidActual(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	_, err := HaveUPPField("foo", "bar").Match("not an entry")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestHaveTransactionIDAndMonitoringEvent(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ulog, capture := newTestCapture()
+
+	ulog.WithMonitoringEvent("Map", "tid_test", "Annotations").WithUUID("test-uuid").WithValidFlag(true).Info("mapped")
+	e := capture.LastEntry()
+
+	g.Expect(e).To(HaveTransactionID("tid_test"))
+	g.Expect(e).To(HaveUUID("test-uuid"))
+	g.Expect(e).To(HaveValidFlag(true))
+	g.Expect(e).To(HaveMonitoringEvent("Map", "tid_test", "Annotations"))
+	g.Expect(e).NotTo(HaveMonitoringEvent("Map", "tid_test", "Concepts"))
+}
+
+func TestMatchersWithCustomKeys(t *testing.T) {
+	g := NewGomegaWithT(t)
+	conf := logger.KeyNamesConfig{KeyTransactionID: "test-transaction-id-key", KeyEventName: "test-event-name-key"}
+	ulog, capture := newTestCapture(conf)
+
+	ulog.WithMonitoringEvent("Map", "tid_test", "Annotations").Info("mapped")
+	e := capture.LastEntry()
+
+	g.Expect(e).To(HaveTransactionID("tid_test", conf))
+	g.Expect(e).To(HaveMonitoringEvent("Map", "tid_test", "Annotations", conf))
+	g.Expect(e).NotTo(HaveTransactionID("tid_test"))
+}
+
+func TestContainLogEntry(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ulog, capture := newTestCapture()
+
+	ulog.WithTransactionID("tid_1").Info("first")
+	ulog.WithTransactionID("tid_2").Warn("second")
+
+	g.Expect(capture).To(ContainLogEntry(HaveTransactionID("tid_2")))
+	g.Expect(capture.Entries()).To(ContainLogEntry(HaveTransactionID("tid_1")))
+	g.Expect(capture).NotTo(ContainLogEntry(HaveTransactionID("tid_1"), HaveUPPField("missing", "value")))
+
+	_, err := ContainLogEntry().Match("not entries")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestContainLogEntryEventually(t *testing.T) {
+	g := NewGomegaWithT(t)
+	ulog, capture := newTestCapture()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		ulog.WithMonitoringEvent("Publish", "tid_async", "Article").Info("published")
+	}()
+
+	g.Eventually(capture.Entries).Should(ContainLogEntry(HaveMonitoringEvent("Publish", "tid_async", "Article")))
+	g.Expect(capture.LastEntry().Level).To(Equal(logrus.InfoLevel))
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TestingT is the part of testing.TB used by the assertions of this package.
+// Besides *testing.T, it is implemented by *testing.B, *testing.F, SoftAssert and custom test harnesses.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+	Helper()
+}
+
+// SoftAssert collects the failures of the assertions instead of reporting them one by one,
+// so that all the mismatches of a log entry or a test can be reported at once:
+//
+//	soft := test.NewSoftAssert(t)
+//	defer soft.Report()
+//	test.Assert(soft, entry).HasTransactionID("tid_test").HasUUID(uuid)
+type SoftAssert struct {
+	t          TestingT
+	mu         sync.Mutex
+	mismatches []string
+}
+
+// NewSoftAssert returns SoftAssert reporting the collected failures to t.
+func NewSoftAssert(t TestingT) *SoftAssert {
+	return &SoftAssert{t: t}
+}
+
+// Errorf records the failure.
+func (s *SoftAssert) Errorf(format string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mismatches = append(s.mismatches, fmt.Sprintf(format, args...))
+}
+
+// Helper implements TestingT. The failures are reported from the caller of Report.
+func (s *SoftAssert) Helper() {}
+
+// Failed reports whether any failure was recorded.
+func (s *SoftAssert) Failed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.mismatches) > 0
+}
+
+// Mismatches returns the recorded failures.
+func (s *SoftAssert) Mismatches() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.mismatches...)
+}
+
+// Report reports all the recorded failures as a single error, if there are any, and clears them.
+func (s *SoftAssert) Report() {
+	s.t.Helper()
+	s.mu.Lock()
+	mismatches := s.mismatches
+	s.mismatches = nil
+	s.mu.Unlock()
+
+	if len(mismatches) == 0 {
+		return
+	}
+	s.t.Errorf("%d log assertions failed:\n\t%s", len(mismatches), strings.Join(mismatches, "\n\t"))
+}
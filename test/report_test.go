@@ -0,0 +1,80 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ TestingT = (*testing.T)(nil)
+	_ TestingT = (*testing.B)(nil)
+	_ TestingT = testing.TB(nil)
+	_ TestingT = (*SoftAssert)(nil)
+)
+
+// recordingT is a custom test harness recording the reported errors.
+type recordingT struct {
+	errors  []string
+	helpers int
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Helper() {
+	r.helpers++
+}
+
+func TestAssertCustomHarness(t *testing.T) {
+	rt := new(recordingT)
+	e := logrus.WithField("foo", "bar")
+
+	Assert(rt, e).HasField("foo", "bar").HasField("foo", "baz").NotHasField("missing")
+
+	assert.Equal(t, []string{`Field "foo" has value "bar", expected "baz"`}, rt.errors)
+	assert.True(t, rt.helpers > 0)
+}
+
+func TestSoftAssertReport(t *testing.T) {
+	rt := new(recordingT)
+	soft := NewSoftAssert(rt)
+	e := logrus.WithField("transaction_id", "tid_another").WithField("uuid", "test-uuid")
+	e.Message = "a message"
+
+	Assert(soft, e).HasTransactionID("tid_test").HasUUID("test-uuid").HasMessage("another message")
+	assert.True(t, soft.Failed())
+	assert.Len(t, soft.Mismatches(), 2)
+	assert.Empty(t, rt.errors, "The failures should not be reported before Report")
+
+	soft.Report()
+	assert.Equal(t, []string{"2 log assertions failed:\n" +
+		"\tField \"transaction_id\" has value \"tid_another\", expected \"tid_test\"\n" +
+		"\tEntry has message \"a message\", expected \"another message\""}, rt.errors)
+	assert.False(t, soft.Failed())
+
+	soft.Report()
+	assert.Len(t, rt.errors, 1, "The reported failures should be cleared")
+}
+
+func TestSoftAssertNoFailures(t *testing.T) {
+	mockT := new(testing.T)
+	soft := NewSoftAssert(mockT)
+
+	Assert(soft, logrus.WithField("foo", "bar")).HasField("foo", "bar")
+	AssertEntries(soft, nil).HasCount(0)
+	soft.Report()
+
+	assert.False(t, soft.Failed())
+	assert.False(t, mockT.Failed())
+}
+
+func BenchmarkAssert(b *testing.B) {
+	e := logrus.WithField("transaction_id", "tid_test")
+	for i := 0; i < b.N; i++ {
+		Assert(b, e).HasTransactionID("tid_test")
+	}
+}